@@ -0,0 +1,38 @@
+// File cmd/dvonn-cli/main.go
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"dvonn_go/internal/game"               // 规则与状态
+	console "dvonn_go/internal/ui/console" // 终端渲染 / 输入层
+)
+
+func main() {
+	var cfg console.Config
+	var aiSide string
+	flag.StringVar(&cfg.Mode, "mode", "pve", "游戏模式：pvp 或 pve")
+	flag.StringVar(&aiSide, "ai", "white", "pve 模式下 AI 执子方：white 或 black")
+	flag.IntVar(&cfg.Depth, "depth", 4, "AI 搜索深度")
+	flag.BoolVar(&cfg.Auto, "auto", false, "自动填充第一阶段棋子")
+	flag.BoolVar(&cfg.Unicode, "unicode", false, "棋盘使用 Unicode 线条")
+	flag.BoolVar(&cfg.Color, "color", false, "棋盘使用 ANSI 颜色")
+	flag.Parse()
+
+	switch aiSide {
+	case "white":
+		cfg.AIPlayer = game.PWhite
+	case "black":
+		cfg.AIPlayer = game.PBlack
+	default:
+		log.Fatalf("unknown -ai side %q", aiSide)
+	}
+
+	if err := console.Run(os.Stdin, os.Stdout, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// go build -o dvonn-cli ./cmd/dvonn-cli
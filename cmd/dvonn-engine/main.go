@@ -0,0 +1,17 @@
+// File cmd/dvonn-engine/main.go
+package main
+
+import (
+	"log"
+	"os"
+
+	"dvonn_go/internal/engine" // 文本引擎协议
+)
+
+func main() {
+	if err := engine.Run(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// go build -o dvonn-engine ./cmd/dvonn-engine
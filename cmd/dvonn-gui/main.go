@@ -3,48 +3,80 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"log"
+	"os"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"dvonn_go/internal/game"         // 规则与状态
+	"dvonn_go/internal/netplay"      // 局域网对局
 	ui "dvonn_go/internal/ui/ebiten" // GUI 渲染 / 输入层
 )
 
 var autoPlace bool
 var mode string
+var replayPath string
+var replaySpeed time.Duration
+var netAddr string
+var seat string
+var lobbyName string
+var fullscreen bool
+var paletteName string
+var narrateTo string
+var rotate bool
+var clockSpec string
+var themePath string
 
 func init() {
 	// 解析命令行参数
 	flag.BoolVar(&autoPlace, "auto", false, "是否自动填充第一阶段棋子 (default: false)")
-	flag.StringVar(&mode, "mode", "pve", "游戏模式：pvp 或 pve (default: pvp)")
+	flag.StringVar(&mode, "mode", "menu", "游戏模式：menu（主菜单）、pvp、pve、host（局域网建局）、join（加入）、watch（观战）或 lobby（大厅）")
+	flag.StringVar(&netAddr, "addr", ":7777", "host 的监听地址 / join、watch 的主机地址")
+	flag.StringVar(&seat, "seat", "white", "host 模式下本方执子：white 或 black")
+	flag.StringVar(&lobbyName, "name", defaultName(), "host 模式下在局域网大厅中显示的名称")
+	flag.StringVar(&replayPath, "replay", "", "回放指定的对局记录（JSON）")
+	flag.BoolVar(&fullscreen, "fullscreen", false, "以全屏启动（F11 随时切换）")
+	flag.StringVar(&paletteName, "palette", "", "配色：standard、colour-blind 或 high-contrast（默认沿用设置）")
+	flag.StringVar(&narrateTo, "narrate", "", "把每步着法以文字播报到 stdout 或指定文件，供读屏软件使用")
+	flag.BoolVar(&rotate, "rotate", false, "棋盘旋转 180°，从另一侧看棋（F 键随时翻转）")
+	flag.StringVar(&clockSpec, "clock", "", "pvp / pve 的用时规则：5m（包干）、5m+3s（加秒）或 10m/3x30s（读秒）")
+	flag.StringVar(&themePath, "theme", "", "主题目录或 .zip（含 theme.json），默认沿用设置")
+	flag.DurationVar(&replaySpeed, "speed", time.Second, "回放自动播放的每步间隔")
 	flag.Parse()
 }
 
 func main() {
-	// 创建初始状态，传入模式控制自动填充棋子与模式选择
-	gs := game.StartState()
-
-	// 如果是 PvE 模式并且启用了自动填充，则自动填充第一阶段棋子
-	if mode == "pve" {
-		gs = game.FillPhase1Auto(&gs)
-		gs.Phase = game.Phase2
-		gs.Turn = game.MoveBlack
+	applyAccessibility()
+	applyBoardView()
+	if replayPath != "" {
+		runReplay()
+		return
 	}
 
-	// 如果是 PvP 模式且启用了自动填充，则由游戏逻辑自动放置第一阶段的棋子
-	if mode == "pvp" && autoPlace {
-		gs = game.FillPhase1Auto(&gs)
+	if mode == "host" || mode == "join" || mode == "watch" {
+		runNet()
+		return
+	}
+	if mode == "lobby" {
+		runLobby()
+		return
+	}
+	if mode == "menu" {
+		runMenu()
+		return
 	}
 
+	// 创建初始状态：PvE 自动摆子并进入跳子阶段，PvP 按 -auto 决定是否自动摆子
+	gs := ui.NewGameState(mode, autoPlace)
+
 	// 创建初始 GameView（持有 GameState）
 	view := ui.NewGameView(gs, mode)
+	view.SetTimeControl(parseClock())
 
-	// 窗口参数与 GameView.Layout 对齐
-	ebiten.SetWindowSize(1024, 500)
-	ebiten.SetWindowTitle("DVONN – Ebiten GUI")
-	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeDisabled)
+	setupWindow("DVONN – Ebiten GUI")
 
 	// 限制更新／渲染循环为每秒最多 30 次
 	ebiten.SetTPS(30)
@@ -55,4 +87,160 @@ func main() {
 	}
 }
 
+// runMenu 打开主菜单，由菜单选择模式并开局；选项保存在 ui.SettingsFile
+func runMenu() {
+	settings, err := ui.LoadSettings(ui.SettingsFile)
+	if err != nil {
+		log.Printf("settings ignored: %v", err)
+	}
+	if fullscreen {
+		settings.Fullscreen = true
+	}
+	if clockSpec != "" {
+		settings.Clock = parseClock().String()
+	}
+	setupWindow("DVONN")
+	ebiten.SetTPS(30)
+	app := ui.NewApp(settings)
+	applyAccessibility() // 命令行参数优先于保存的设置
+	applyBoardView()
+	if err := ebiten.RunGame(app); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runNet 建立或加入局域网对局并打开窗口
+func runNet() {
+	gs := game.StartState()
+	var conn *netplay.Conn
+	var err error
+	if mode == "host" {
+		if autoPlace {
+			gs = game.FillPhase1Auto(&gs)
+		}
+		pl := game.PWhite
+		if seat == "black" {
+			pl = game.PBlack
+		}
+		conn, err = netplay.Host(netAddr, pl, &gs)
+	} else if mode == "join" {
+		// 加入方从空棋盘开始，主机的着法记录在握手后补齐
+		conn, err = netplay.Join(netAddr)
+	} else {
+		// 观众同样从空棋盘开始，快速重放已有着法后实时跟进
+		conn, err = netplay.Watch(netAddr)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+	log.Println(conn.Status())
+	if mode == "host" {
+		// 对手连上前在局域网上公告本局
+		if a, err := conn.Announce(netplay.BroadcastTarget(), lobbyName); err != nil {
+			log.Printf("announce disabled: %v", err)
+		} else {
+			defer a.Close()
+		}
+	}
+
+	view := ui.NewNetGameView(gs, mode, conn)
+	title := "DVONN – " + conn.Seat().String() + " (" + mode + ")"
+	if conn.Spectator() {
+		title = "DVONN – spectator"
+	}
+	setupWindow(title)
+	ebiten.SetTPS(30)
+	if err := ebiten.RunGame(view); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runLobby 监听局域网公告并打开大厅，选中对局后直接进入
+func runLobby() {
+	browser, err := netplay.Browse(fmt.Sprintf(":%d", netplay.DiscoveryPort))
+	if err != nil {
+		log.Fatal(err)
+	}
+	setupWindow("DVONN – Lobby")
+	ebiten.SetTPS(30)
+	if err := ebiten.RunGame(ui.NewLobbyView(browser)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// setupWindow 设置可缩放窗口；画布随窗口大小与设备缩放因子重排（见 ui.layoutScreen）
+func setupWindow(title string) {
+	ebiten.SetWindowSize(1024, 600)
+	ebiten.SetWindowSizeLimits(640, 400, -1, -1)
+	ebiten.SetWindowTitle(title)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetFullscreen(fullscreen)
+}
+
+// applyAccessibility 应用 -palette 与 -narrate
+func applyAccessibility() {
+	if paletteName != "" {
+		if err := ui.SetPalette(paletteName); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if narrateTo != "" {
+		if err := ui.SetNarration(narrateTo); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// parseClock 解析 -clock，格式错误时退出
+func parseClock() game.TimeControl {
+	tc, err := game.ParseTimeControl(clockSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return tc
+}
+
+// applyBoardView 应用 -rotate 与 -theme
+func applyBoardView() {
+	if themePath != "" {
+		warnings, err := ui.UseTheme(themePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, w := range warnings {
+			log.Print(w)
+		}
+	}
+	if rotate {
+		ui.SetRotated(true)
+	}
+}
+
+func defaultName() string {
+	h, err := os.Hostname()
+	if err != nil {
+		return "dvonn"
+	}
+	return h
+}
+
+// runReplay 加载记录并打开回放窗口
+func runReplay() {
+	rec, err := game.LoadRecord(replayPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	view, err := ui.NewReplayView(rec, replaySpeed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	setupWindow("DVONN – Replay")
+	ebiten.SetTPS(30)
+	if err := ebiten.RunGame(view); err != nil {
+		log.Fatal(err)
+	}
+}
+
 // go build -ldflags="-s -w" -gcflags="all=-trimpath=${PWD}" -asmflags="all=-trimpath=${PWD}" -o dvonn.exe .\cmd\dvonn-gui\main.go
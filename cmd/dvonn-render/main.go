@@ -0,0 +1,182 @@
+// File cmd/dvonn-render/main.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dvonn_go/internal/game"   // 规则、局面串与对局记录
+	"dvonn_go/internal/render" // 无窗口渲染
+)
+
+func main() {
+	position := flag.String("position", "", "要渲染的局面串（见 game.FormatPosition）")
+	recordPath := flag.String("record", "", "要渲染的对局记录（JSON）")
+	ply := flag.Int("ply", -1, "与 -record 合用：渲染第 n 步之后的局面，-1 为终局")
+	last := flag.Bool("last", false, "与 -record 合用：标出最后一步（跳子画箭头，摆子高亮）")
+	out := flag.String("o", "board.png", "输出文件，扩展名 .png、.svg 或 .gif（.gif 需 -record，导出整局动画）")
+	framesDir := flag.String("frames", "", "与 -record 合用：把整局动画导出为该目录下的 PNG 序列")
+	delay := flag.Duration("delay", 800*time.Millisecond, "动画：每步跳子后的停留时长")
+	placeDelay := flag.Duration("place-delay", 150*time.Millisecond, "动画：每步摆子后的停留时长")
+	frameDelay := flag.Duration("frame-delay", 40*time.Millisecond, "动画：过渡帧间隔")
+	size := flag.Int("size", render.DefaultSize, "图像宽度（像素）")
+	labels := flag.Bool("labels", false, "标注标准坐标")
+	highlight := flag.String("highlight", "", "高亮的格子，逗号分隔，如 C3,E3")
+	arrows := flag.String("arrows", "", "箭头，逗号分隔，如 C3-E3,D2-D4")
+	printPos := flag.Bool("print", false, "同时把局面串打印到标准输出")
+	flag.Parse()
+
+	if *framesDir != "" || strings.EqualFold(filepath.Ext(*out), ".gif") {
+		opts := render.AnimOptions{
+			Options:    render.Options{Size: *size, Labels: *labels},
+			Delay:      *delay,
+			PlaceDelay: *placeDelay,
+			FrameDelay: *frameDelay,
+		}
+		if err := animate(*recordPath, *out, *framesDir, opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	gs, lastMove, err := loadState(*position, *recordPath, *ply)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := render.Options{Size: *size, Labels: *labels}
+	if opts.Highlight, err = parseCoords(*highlight); err != nil {
+		log.Fatal(err)
+	}
+	if opts.Arrows, err = parseArrows(*arrows); err != nil {
+		log.Fatal(err)
+	}
+	if *last {
+		switch m := lastMove.(type) {
+		case game.PlaceMove:
+			opts.Highlight = append(opts.Highlight, m.At)
+		case game.JumpMove:
+			opts.Arrows = append(opts.Arrows, render.Arrow{From: m.From, To: m.To})
+		}
+	}
+
+	if err := write(*out, &gs, opts); err != nil {
+		log.Fatal(err)
+	}
+	if *printPos {
+		fmt.Println(game.FormatPosition(&gs))
+	}
+}
+
+// loadState 从局面串或对局记录得到要渲染的局面，以及到达它的最后一步（可能为 nil）
+func loadState(position, recordPath string, ply int) (game.GameState, game.Move, error) {
+	switch {
+	case position != "" && recordPath != "":
+		return game.GameState{}, nil, errors.New("use either -position or -record, not both")
+	case position != "":
+		gs, err := game.ParsePosition(position)
+		return gs, nil, err
+	case recordPath != "":
+		rec, err := game.LoadRecord(recordPath)
+		if err != nil {
+			return game.GameState{}, nil, err
+		}
+		states, moves, err := rec.Replay()
+		if err != nil {
+			return game.GameState{}, nil, err
+		}
+		if ply < 0 || ply > len(moves) {
+			ply = len(moves)
+		}
+		var mv game.Move
+		if ply > 0 {
+			mv = moves[ply-1]
+		}
+		return states[ply], mv, nil
+	default:
+		gs := game.StartState()
+		return gs, nil, nil
+	}
+}
+
+func write(path string, gs *game.GameState, opts render.Options) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		err = render.SVG(f, gs, opts)
+	default:
+		err = render.PNG(f, gs, opts)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// animate 导出整局动画：-frames 给出时写 PNG 序列，否则写 GIF
+func animate(recordPath, out, framesDir string, opts render.AnimOptions) error {
+	if recordPath == "" {
+		return errors.New("animation needs -record")
+	}
+	rec, err := game.LoadRecord(recordPath)
+	if err != nil {
+		return err
+	}
+	if framesDir != "" {
+		return render.PNGFrames(framesDir, rec, opts)
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	err = render.GIF(f, rec, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func parseCoords(s string) ([]game.Coordinate, error) {
+	var out []game.Coordinate
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		c, err := game.ParseCoord(f)
+		if err != nil {
+			return nil, fmt.Errorf("bad coordinate %q", f)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func parseArrows(s string) ([]render.Arrow, error) {
+	var out []render.Arrow
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(f, "-")
+		if !ok {
+			return nil, fmt.Errorf("bad arrow %q, want FROM-TO", f)
+		}
+		cs, err := parseCoords(from + "," + to)
+		if err != nil || len(cs) != 2 {
+			return nil, fmt.Errorf("bad arrow %q, want FROM-TO", f)
+		}
+		out = append(out, render.Arrow{From: cs[0], To: cs[1]})
+	}
+	return out, nil
+}
+
+// go build -o dvonn-render ./cmd/dvonn-render
@@ -0,0 +1,79 @@
+// File cmd/dvonn-server/main.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"dvonn_go/internal/netplay" // 局域网公告
+	"dvonn_go/internal/server"  // HTTP/JSON 对局服务
+)
+
+// shutdownTimeout 收到退出信号后等待进行中请求的最长时间
+const shutdownTimeout = 5 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8080", "监听地址")
+	announce := flag.Bool("announce", true, "在局域网上广播本服务，供 GUI 大厅发现")
+	name := flag.String("name", hostname(), "大厅中显示的名称")
+	idle := flag.Duration("idle", server.DefaultIdleTimeout, "对局无变化多久后清除，0 表示不清除")
+	flag.Parse()
+
+	srv := server.New()
+	srv.IdleTimeout = *idle
+	var a *netplay.Announcer
+	if *announce {
+		var err error
+		a, err = netplay.Announce(netplay.BroadcastTarget(), func() (netplay.Announcement, bool) {
+			return netplay.Announcement{
+				Kind:  netplay.KindServer,
+				Name:  *name,
+				Addr:  "http://" + *addr,
+				Games: srv.Count(),
+			}, true
+		})
+		if err != nil {
+			log.Printf("announce disabled: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	hs := &http.Server{Addr: *addr, Handler: srv}
+	errc := make(chan error, 1)
+	go func() { errc <- hs.ListenAndServe() }()
+	log.Printf("dvonn-server listening on %s", *addr)
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		log.Printf("dvonn-server shutting down")
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		err = hs.Shutdown(sctx)
+		cancel()
+	}
+	if a != nil {
+		a.Close()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+func hostname() string {
+	h, err := os.Hostname()
+	if err != nil {
+		return "dvonn-server"
+	}
+	return h
+}
+
+// go build -o dvonn-server ./cmd/dvonn-server
@@ -0,0 +1,72 @@
+// File internal/ai/analyzer.go
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"sync"
+	"sync/atomic"
+)
+
+// Analyzer 在后台对一个局面做迭代加深分析，随时可读取当前最深一层的结果
+type Analyzer struct {
+	MaxDepth int // 迭代加深的最大深度
+	MultiPV  int // 保留的候选着法数
+
+	mu    sync.Mutex
+	lines []Line
+	depth int
+	stop  *atomic.Bool
+}
+
+// NewAnalyzer 创建后台分析器
+func NewAnalyzer(maxDepth, multiPV int) *Analyzer {
+	return &Analyzer{MaxDepth: maxDepth, MultiPV: multiPV}
+}
+
+// Start 停掉旧的分析并开始分析 gs 的拷贝；非跳子阶段只清空结果
+func (a *Analyzer) Start(gs game.GameState) {
+	a.Stop()
+
+	a.mu.Lock()
+	a.lines, a.depth = nil, 0
+	stop := &atomic.Bool{}
+	a.stop = stop
+	a.mu.Unlock()
+
+	if gs.Phase != game.Phase2 || game.IsGameOver(&gs) {
+		return
+	}
+	gs.Board = gs.Board.Clone()
+
+	go func() {
+		for d := 1; d <= a.MaxDepth; d++ {
+			lines := searchRoot(&gs, d, stop)
+			if stop.Load() {
+				return
+			}
+			lines = topLines(lines, a.MultiPV)
+
+			a.mu.Lock()
+			if a.stop == stop {
+				a.lines, a.depth = lines, d
+			}
+			a.mu.Unlock()
+		}
+	}()
+}
+
+// Stop 终止正在进行的分析（已得结果保留）
+func (a *Analyzer) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil {
+		a.stop.Store(true)
+	}
+}
+
+// Lines 返回当前结果的拷贝及其完成深度
+func (a *Analyzer) Lines() ([]Line, int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Line(nil), a.lines...), a.depth
+}
@@ -0,0 +1,77 @@
+// File internal/ai/mover.go
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Mover 在后台为 AI 搜索一步，界面每帧用 Result 取结果，搜索期间不阻塞
+type Mover struct {
+	mu       sync.Mutex
+	gen      int // 每次 Start / Stop +1，旧搜索的结果据此作废
+	stop     *atomic.Bool
+	move     game.JumpMove
+	ready    bool
+	thinking bool
+}
+
+// Start 停掉旧的搜索并为 gs 的拷贝搜索一步：budget > 0 时按时限迭代加深到 depth（同 SearchTimed），
+// 否则定深搜索（同 SearchBestMove）
+func (m *Mover) Start(gs game.GameState, depth int, budget time.Duration) {
+	m.Stop()
+
+	m.mu.Lock()
+	m.gen++
+	gen := m.gen
+	stop := &atomic.Bool{}
+	m.stop, m.thinking = stop, true
+	m.mu.Unlock()
+
+	gs.Board = gs.Board.Clone()
+	go func() {
+		var best game.JumpMove
+		if budget > 0 {
+			best = searchTimed(&gs, depth, budget, stop)
+		} else if lines := searchRoot(&gs, depth, stop); len(lines) > 0 {
+			best = lines[0].Move
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.gen == gen {
+			m.move, m.ready = best, true
+		}
+	}()
+}
+
+// Stop 终止正在进行的搜索并丢弃其结果
+func (m *Mover) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gen++
+	if m.stop != nil {
+		m.stop.Store(true)
+	}
+	m.ready, m.thinking = false, false
+}
+
+// Thinking 已 Start 且结果尚未被取走
+func (m *Mover) Thinking() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.thinking
+}
+
+// Result 取走搜索结果；尚未完成时返回 false
+func (m *Mover) Result() (game.JumpMove, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.ready {
+		return game.JumpMove{}, false
+	}
+	m.ready, m.thinking = false, false
+	return m.move, true
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"testing"
+	"time"
+)
+
+// movementState 返回一个自动摆完子、轮到跳子的局面
+func movementState() game.GameState {
+	gs := game.StartState()
+	gs = game.FillPhase1Auto(&gs)
+	gs.Phase, gs.Turn = game.Phase2, game.MoveBlack
+	return gs
+}
+
+func waitMove(t *testing.T, m *Mover) game.JumpMove {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if mv, ok := m.Result(); ok {
+			return mv
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no result from Mover")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMover(t *testing.T) {
+	gs := movementState()
+	for _, budget := range []time.Duration{0, 50 * time.Millisecond} {
+		var m Mover
+		m.Start(gs, 1, budget)
+		if !m.Thinking() {
+			t.Fatal("not thinking after Start")
+		}
+		mv := waitMove(t, &m)
+		if !game.ValidMove(&gs.Board, mv) || mv.Player != game.ToMove(&gs) {
+			t.Errorf("budget %v: invalid move %v", budget, mv)
+		}
+		if m.Thinking() {
+			t.Error("still thinking after the result was taken")
+		}
+	}
+}
+
+func TestMoverStopDiscards(t *testing.T) {
+	var m Mover
+	m.Start(movementState(), 3, 0)
+	m.Stop()
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := m.Result(); ok || m.Thinking() {
+		t.Error("stopped search still delivered a result")
+	}
+}
@@ -0,0 +1,23 @@
+// File internal/ai/placement.go
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"math/rand"
+)
+
+// ChoosePlacement 为摆子阶段选一个空格（随机），与 FillPhase1Auto 的策略一致
+func ChoosePlacement(gs *game.GameState) game.PlaceMove {
+	var empty []game.Coordinate
+	game.ForEachPlayable(func(c game.Coordinate) {
+		st := gs.Board.Cells[c.X][c.Y]
+		if st == nil || len(*st) == 0 {
+			empty = append(empty, c)
+		}
+	})
+	mv := game.PlaceMove{Piece: game.NextPiece(gs)}
+	if len(empty) > 0 {
+		mv.At = empty[rand.Intn(len(empty))]
+	}
+	return mv
+}
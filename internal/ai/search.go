@@ -7,16 +7,57 @@ import (
 	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
-// 并行搜索结果结构
-type rootResult struct {
-	mv    game.JumpMove
-	score int
+// Line 为一条根着法的搜索结果：得分（走子方视角）、完成深度与主要变例
+type Line struct {
+	Move  game.JumpMove
+	Score int
+	Depth int
+	PV    []game.JumpMove // PV[0] == Move
 }
 
 // SearchBestMove 入口：迭代加深 αβ，根节点多核并行，返回最佳 JumpMove
 func SearchBestMove(gs *game.GameState, depth int) game.JumpMove {
+	lines := searchRoot(gs, depth, nil)
+	if len(lines) == 0 {
+		// 如果没有有效移动，返回空移动
+		return game.JumpMove{}
+	}
+	return lines[0].Move
+}
+
+// SearchOptions 控制一次搜索
+type SearchOptions struct {
+	Depth   int // 搜索深度（层）
+	MultiPV int // 返回的候选着法数；0 或 1 时只返回最佳一条，AllMoves 返回全部
+}
+
+// AllMoves 作为 SearchOptions.MultiPV 时返回全部根着法
+const AllMoves = -1
+
+// Search 按 opts 搜索 gs，返回得分从高到低的前 MultiPV 条着法（走子方视角）；
+// 无合法跳子时返回 nil
+func Search(gs *game.GameState, opts SearchOptions) []Line {
+	lines := searchRoot(gs, opts.Depth, nil)
+	n := opts.MultiPV
+	if n == 0 {
+		n = 1
+	}
+	return topLines(lines, n)
+}
+
+// topLines 截取前 n 条；n<=0 表示全部
+func topLines(lines []Line, n int) []Line {
+	if n > 0 && len(lines) > n {
+		return lines[:n]
+	}
+	return lines
+}
+
+// searchRoot 并行搜索每个根着法并给出完整得分；stop 被置位时返回 nil
+func searchRoot(gs *game.GameState, depth int, stop *atomic.Bool) []Line {
 	// 把 GOMAXPROCS 设为 CPU 核心数
 	runtime.GOMAXPROCS(runtime.NumCPU() - 1)
 
@@ -24,14 +65,12 @@ func SearchBestMove(gs *game.GameState, depth int) game.JumpMove {
 
 	// 生成所有有效的 JumpMove
 	jmoves := getValidJumpMoves(gs, me)
-
 	if len(jmoves) == 0 {
-		// 如果没有有效移动，返回空移动
-		return game.JumpMove{}
+		return nil
 	}
 
 	// 并行搜每个根走法
-	results := make(chan rootResult, len(jmoves))
+	results := make(chan Line, len(jmoves))
 	var wg sync.WaitGroup
 	for _, mv := range jmoves {
 		wg.Add(1)
@@ -40,40 +79,80 @@ func SearchBestMove(gs *game.GameState, depth int) game.JumpMove {
 			// 每个 goroutine 用自己独立的 transposition table，避免加锁开销
 			tt := NewTT()
 
-			// 克隆局面并执行一步
-			clone := *gs
-			clone.Board = gs.Board.Clone()
+			// 克隆局面并执行一步（不调用 RunMovementPhase 避免错误打印）
+			clone := playOn(gs, m)
 
-			// 直接应用移动，不再调用 RunMovementPhase 避免错误打印
-			clone.Board = game.Apply(m, &clone.Board)
-			clone.Turn = game.GetNextTurn(&clone.Board, me)
-
-			// 检查是否还有合法移动
-			if !game.HasAnyLegalMoves(&clone.Board, clone.Turn) {
-				clone.Turn = game.End
+			// 深度减 1，alpha-beta 搜索；对手走时翻转视角
+			next := nextToMove(&clone, me)
+			var v int
+			var pv []game.JumpMove
+			if next == me {
+				v, pv = alphabeta(&clone, me, depth-1, math.MinInt32+1, math.MaxInt32-1, tt, stop)
+			} else {
+				v, pv = alphabeta(&clone, next, depth-1, math.MinInt32+1, math.MaxInt32-1, tt, stop)
+				v = -v
+			}
+			results <- Line{
+				Move:  m,
+				Score: v,
+				Depth: depth,
+				PV:    append([]game.JumpMove{m}, pv...),
 			}
-
-			// 深度减 1，alpha-beta 搜索
-			v, _ := alphabeta(&clone, depth-1,
-				math.MinInt32+1, math.MaxInt32-1,
-				me, tt)
-			// 因为这里返回的是下一手得分，父节点还要翻转视角
-			results <- rootResult{mv: m, score: -v}
 		}(mv)
 	}
 
 	// 等待所有并行完成
 	wg.Wait()
 	close(results)
+	if stop != nil && stop.Load() {
+		return nil
+	}
 
-	// 汇总选最优
-	best := rootResult{score: math.MinInt32}
+	// 汇总并按得分排序（同分保持走法生成顺序）
+	lines := make([]Line, 0, len(jmoves))
 	for r := range results {
-		if r.score > best.score {
-			best = r
+		lines = append(lines, r)
+	}
+	order := make(map[game.JumpMove]int, len(jmoves))
+	for i, m := range jmoves {
+		order[m] = i
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Score != lines[j].Score {
+			return lines[i].Score > lines[j].Score
 		}
+		return order[lines[i].Move] < order[lines[j].Move]
+	})
+	return lines
+}
+
+// playOn 返回在 gs 上执行 m 之后的新局面（深拷贝棋盘）
+func playOn(gs *game.GameState, m game.JumpMove) game.GameState {
+	clone := *gs
+	clone.Board = gs.Board.Clone()
+	clone.Board = game.Apply(m, &clone.Board)
+	clone.Turn = game.GetNextTurn(&clone.Board, m.Player)
+
+	// 检查是否还有合法移动
+	if !game.HasAnyLegalMoves(&clone.Board, clone.Turn) {
+		clone.Turn = game.End
+	}
+	return clone
+}
+
+// nextToMove 给出 gs 中的行棋方；终局时视为 justPlayed 的对手
+func nextToMove(gs *game.GameState, justPlayed game.Player) game.Player {
+	if gs.Turn == game.End {
+		return opponent(justPlayed)
+	}
+	return game.TurnStateToPlayer(gs.Turn)
+}
+
+func opponent(p game.Player) game.Player {
+	if p == game.PWhite {
+		return game.PBlack
 	}
-	return best.mv
+	return game.PWhite
 }
 
 // getValidJumpMoves 获取所有有效的跳子移动
@@ -98,60 +177,87 @@ func getValidJumpMoves(gs *game.GameState, player game.Player) []game.JumpMove {
 	return validJumpMoves
 }
 
-// alphabeta 使用 TT
-func alphabeta(gs *game.GameState, depth, alpha, beta int, me game.Player, tt *TT) (int, game.Move) {
-	hash := Hash(&gs.Board, game.TurnStateToPlayer(gs.Turn))
-	if v, mv, ok := tt.Lookup(hash, depth); ok {
-		return v, mv
+// alphabeta 使用 TT 的 negamax；pl 为本节点行棋方，返回其视角得分与主要变例
+func alphabeta(gs *game.GameState, pl game.Player, depth, alpha, beta int, tt *TT, stop *atomic.Bool) (int, []game.JumpMove) {
+	if stop != nil && stop.Load() {
+		return 0, nil
+	}
+
+	hash := Hash(&gs.Board, pl)
+	if v, _, ok := tt.Lookup(hash, depth, alpha, beta); ok {
+		return v, tt.pv(gs, pl, depth)
 	}
 
 	// 叶节点
 	if depth == 0 || gs.Turn == game.End {
-		v := Evaluate(&gs.Board, me)
-		tt.Save(hash, depth, v, nil)
+		v := Evaluate(&gs.Board, pl)
+		tt.Save(hash, depth, v, boundExact, nil)
 		return v, nil
 	}
 
-	// 获取当前玩家
-	currentPlayer := game.TurnStateToPlayer(gs.Turn)
-
 	// 生成所有有效的跳子移动
-	jmoves := getValidJumpMoves(gs, currentPlayer)
+	jmoves := getValidJumpMoves(gs, pl)
 
 	if len(jmoves) == 0 {
 		// 没有有效移动，直接评估
-		v := Evaluate(&gs.Board, me)
-		tt.Save(hash, depth, v, nil)
+		v := Evaluate(&gs.Board, pl)
+		tt.Save(hash, depth, v, boundExact, nil)
 		return v, nil
 	}
 
+	origAlpha := alpha
 	var bestMove game.Move
+	var bestPV []game.JumpMove
 	for _, mv := range jmoves {
-		// 复制 GameState
-		clone := *gs
-		clone.Board = gs.Board.Clone()
+		clone := playOn(gs, mv)
 
-		// 直接应用移动
-		clone.Board = game.Apply(mv, &clone.Board)
-		clone.Turn = game.GetNextTurn(&clone.Board, currentPlayer)
-
-		// 检查是否还有合法移动
-		if !game.HasAnyLegalMoves(&clone.Board, clone.Turn) {
-			clone.Turn = game.End
+		// 对手无子可走时本方连走，不翻转视角
+		var val int
+		var pv []game.JumpMove
+		if next := nextToMove(&clone, pl); next == pl {
+			val, pv = alphabeta(&clone, pl, depth-1, alpha, beta, tt, stop)
+		} else {
+			val, pv = alphabeta(&clone, next, depth-1, -beta, -alpha, tt, stop)
+			val = -val
 		}
 
-		val, _ := alphabeta(&clone, depth-1, -beta, -alpha, me, tt)
-		val = -val
-
 		if val > alpha {
 			alpha = val
 			bestMove = mv
+			bestPV = append([]game.JumpMove{mv}, pv...)
 			if alpha >= beta {
 				break // β剪枝
 			}
 		}
 	}
 
-	tt.Save(hash, depth, alpha, bestMove)
-	return alpha, bestMove
+	bound := boundExact
+	switch {
+	case alpha >= beta:
+		bound, bestMove = boundLower, nil
+	case alpha <= origAlpha:
+		bound = boundUpper
+	}
+	tt.Save(hash, depth, alpha, bound, bestMove)
+	return alpha, bestPV
+}
+
+// pv 沿 TT 中精确值节点记下的最佳着法重建主要变例，最多 depth 步
+func (tt *TT) pv(gs *game.GameState, pl game.Player, depth int) []game.JumpMove {
+	var line []game.JumpMove
+	cur := *gs
+	for ; depth > 0 && cur.Turn != game.End; depth-- {
+		e, ok := tt.table[Hash(&cur.Board, pl)]
+		if !ok || e.bound != boundExact {
+			break
+		}
+		jm, isJump := e.move.(game.JumpMove)
+		if !isJump || jm.Player != pl || !game.ValidMove(&cur.Board, jm) {
+			break
+		}
+		line = append(line, jm)
+		cur = playOn(&cur, jm)
+		pl = nextToMove(&cur, pl)
+	}
+	return line
 }
@@ -0,0 +1,131 @@
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"testing"
+)
+
+// negamax 不剪枝、不用 TT 的参考搜索，与 alphabeta 的约定相同
+func negamax(gs *game.GameState, pl game.Player, depth int) int {
+	if depth == 0 || gs.Turn == game.End {
+		return Evaluate(&gs.Board, pl)
+	}
+	jmoves := getValidJumpMoves(gs, pl)
+	if len(jmoves) == 0 {
+		return Evaluate(&gs.Board, pl)
+	}
+	best := -1 << 31
+	for _, mv := range jmoves {
+		clone := playOn(gs, mv)
+		var v int
+		if next := nextToMove(&clone, pl); next == pl {
+			v = negamax(&clone, pl, depth-1)
+		} else {
+			v = -negamax(&clone, next, depth-1)
+		}
+		best = max(best, v)
+	}
+	return best
+}
+
+// testPosition 跳子中盘的一个固定局面：分支不多，参考搜索能很快走完 4 层
+const testPosition = ".,.,.,.,.,.,.,B,BWW/.,WBWB,.,.,.,.,.,WBW,R,WB/B,BWB,R,BWB,.,.,.,.,BWB,.,./R,.,BWB,.,.,.,.,.,W,W/BW,.,.,.,.,.,.,BWB,. 2 w BWWBWBBWWWWWB"
+
+func testState(t *testing.T) game.GameState {
+	t.Helper()
+	gs, err := game.ParsePosition(testPosition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gs
+}
+
+func TestTTLookupBounds(t *testing.T) {
+	tt := NewTT()
+	tt.Save(1, 3, 10, boundExact, nil)
+	tt.Save(2, 3, 10, boundLower, nil)
+	tt.Save(3, 3, 10, boundUpper, nil)
+	for _, tc := range []struct {
+		hash               uint64
+		depth, alpha, beta int
+		ok                 bool
+	}{
+		{1, 3, 20, 30, true}, // 精确值不受窗口限制
+		{1, 4, 0, 30, false}, // 深度不够
+		{1, 2, 0, 30, true},  // 更深的结果可以用于更浅的搜索
+		{2, 3, 0, 10, true},  // 下界 >= beta：剪枝
+		{2, 3, 0, 30, false}, // 下界落在窗口内：真实值未知
+		{3, 3, 10, 30, true}, // 上界 <= alpha：全部低于 alpha
+		{3, 3, 0, 30, false}, // 上界落在窗口内
+		{4, 0, 0, 30, false}, // 没有记录
+	} {
+		v, _, ok := tt.Lookup(tc.hash, tc.depth, tc.alpha, tc.beta)
+		if ok != tc.ok || (ok && v != 10) {
+			t.Errorf("Lookup(%d, depth %d, [%d, %d]) = %d, %v; want ok %v", tc.hash, tc.depth, tc.alpha, tc.beta, v, ok, tc.ok)
+		}
+	}
+}
+
+// TestSearchScoresMatchNegamax 每条根着法的得分须是精确值，与不剪枝的搜索一致
+func TestSearchScoresMatchNegamax(t *testing.T) {
+	const depth = 4
+	gs := testState(t)
+	me := game.TurnStateToPlayer(gs.Turn)
+	lines := Search(&gs, SearchOptions{Depth: depth, MultiPV: AllMoves})
+	if len(lines) == 0 {
+		t.Fatal("no moves")
+	}
+	for _, l := range lines {
+		clone := playOn(&gs, l.Move)
+		want := 0
+		if next := nextToMove(&clone, me); next == me {
+			want = negamax(&clone, me, depth-1)
+		} else {
+			want = -negamax(&clone, next, depth-1)
+		}
+		if l.Score != want {
+			t.Errorf("%s: score %d, want %d", game.FormatMove(l.Move), l.Score, want)
+		}
+	}
+}
+
+// TestSearchPV 主要变例应走满深度（除非对局提前结束），且每步合法
+func TestSearchPV(t *testing.T) {
+	const depth = 4
+	gs := testState(t)
+	for _, l := range Search(&gs, SearchOptions{Depth: depth, MultiPV: AllMoves}) {
+		cur := gs
+		pl := game.TurnStateToPlayer(gs.Turn)
+		for i, mv := range l.PV {
+			if mv.Player != pl || !game.ValidMove(&cur.Board, mv) {
+				t.Fatalf("%s: PV move %d (%s) is illegal", game.FormatMove(l.Move), i, game.FormatMove(mv))
+			}
+			cur = playOn(&cur, mv)
+			pl = nextToMove(&cur, pl)
+		}
+		if len(l.PV) < depth && cur.Turn != game.End && len(getValidJumpMoves(&cur, pl)) > 0 {
+			t.Errorf("%s: PV has %d moves, want %d", game.FormatMove(l.Move), len(l.PV), depth)
+		}
+	}
+}
+
+// TestTTPV TT 命中时主要变例由表中记下的最佳着法重建，而不是截成一步
+func TestTTPV(t *testing.T) {
+	gs := testState(t)
+	me := game.TurnStateToPlayer(gs.Turn)
+	tt := NewTT()
+	_, want := alphabeta(&gs, me, 3, -1<<30, 1<<30, tt, nil)
+	if len(want) < 2 {
+		t.Fatalf("PV %v too short to test", want)
+	}
+	// 同一局面再搜一次直接命中 TT
+	_, got := alphabeta(&gs, me, 3, -1<<30, 1<<30, tt, nil)
+	if len(got) != len(want) {
+		t.Fatalf("PV from TT hit has %d moves, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("PV[%d] = %s, want %s", i, game.FormatMove(got[i]), game.FormatMove(want[i]))
+		}
+	}
+}
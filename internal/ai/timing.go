@@ -0,0 +1,57 @@
+// File internal/ai/timing.go
+package ai
+
+import (
+	"dvonn_go/internal/game"
+	"sync/atomic"
+	"time"
+)
+
+// 用时分配：基本用时按还剩约 movesToGo 步均分，加秒与读秒只用大半，留出余量
+const (
+	movesToGo  = 25
+	minBudget  = 100 * time.Millisecond
+	safetyPart = 4 // 读秒 / 加秒只用 (safetyPart-1)/safetyPart
+)
+
+// AllocateTime 按 p 的棋钟为这一步分配搜索时间
+func AllocateTime(c *game.Clock, p game.Player, now time.Time) time.Duration {
+	left, periods, period := c.Remaining(p, now)
+	budget := left/movesToGo + c.TC.Increment*(safetyPart-1)/safetyPart
+	if periods > 0 {
+		// 读秒内走完不扣次数，可以放心用掉大半次读秒
+		budget = max(budget, period*(safetyPart-1)/safetyPart)
+	} else {
+		budget = min(budget, left/2)
+	}
+	return max(budget, minBudget)
+}
+
+// SearchTimed 在 budget 内迭代加深到 maxDepth，返回最深一层完整搜完的最佳着法。
+// 第一层不受时限约束，保证总有着法可走。
+func SearchTimed(gs *game.GameState, maxDepth int, budget time.Duration) game.JumpMove {
+	return searchTimed(gs, maxDepth, budget, &atomic.Bool{})
+}
+
+// searchTimed 同 SearchTimed；stop 到时由计时器置位，调用方也可提前置位
+func searchTimed(gs *game.GameState, maxDepth int, budget time.Duration, stop *atomic.Bool) game.JumpMove {
+	timer := time.AfterFunc(budget, func() { stop.Store(true) })
+	defer timer.Stop()
+
+	var best game.JumpMove
+	for d := 1; d <= maxDepth; d++ {
+		var s *atomic.Bool
+		if d > 1 {
+			s = stop
+		}
+		lines := searchRoot(gs, d, s)
+		if len(lines) == 0 {
+			break
+		}
+		best = lines[0].Move
+		if stop.Load() {
+			break
+		}
+	}
+	return best
+}
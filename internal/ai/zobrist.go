@@ -110,10 +110,18 @@ func pieceIndex(p game.Piece) int {
 
 // ---------------- TT 结构 ---------------------
 
+// 得分类型：αβ 剪枝后的得分往往只是界
+const (
+	boundExact = iota // 窗口内，精确值
+	boundLower        // β 剪枝，真实值 >= score
+	boundUpper        // 全部低于 α，真实值 <= score
+)
+
 type ttEntry struct {
 	depth int
 	score int
-	move  game.Move
+	bound int
+	move  game.Move // 仅精确值时为最佳着法
 }
 
 type TT struct {
@@ -124,16 +132,24 @@ func NewTT() *TT {
 	return &TT{table: make(map[uint64]ttEntry, 1<<18)}
 }
 
-func (tt *TT) Save(hash uint64, depth, score int, move game.Move) {
+func (tt *TT) Save(hash uint64, depth, score, bound int, move game.Move) {
 	e, ok := tt.table[hash]
-	if !ok || depth > e.depth {
-		tt.table[hash] = ttEntry{depth: depth, score: score, move: move}
+	if !ok || depth >= e.depth {
+		tt.table[hash] = ttEntry{depth: depth, score: score, bound: bound, move: move}
 	}
 }
 
-func (tt *TT) Lookup(hash uint64, depth int) (score int, move game.Move, ok bool) {
+// Lookup 返回深度足够、且在 (alpha, beta) 窗口下可直接采用的得分：
+// 精确值总可采用，下界须 >= beta，上界须 <= alpha
+func (tt *TT) Lookup(hash uint64, depth, alpha, beta int) (score int, move game.Move, ok bool) {
 	e, ok := tt.table[hash]
-	if ok && e.depth >= depth {
+	if !ok || e.depth < depth {
+		return 0, nil, false
+	}
+	switch {
+	case e.bound == boundExact,
+		e.bound == boundLower && e.score >= beta,
+		e.bound == boundUpper && e.score <= alpha:
 		return e.score, e.move, true
 	}
 	return 0, nil, false
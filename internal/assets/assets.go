@@ -0,0 +1,62 @@
+// File internal/assets/assets.go
+package assets
+
+// 棋子贴图；GUI 与无窗口渲染共用
+
+import (
+	"bytes"
+	_ "embed"
+	"image"
+	_ "image/png"
+
+	"dvonn_go/internal/game"
+)
+
+//go:embed red1.png
+var redPNG []byte
+
+//go:embed white1.png
+var whitePNG []byte
+
+//go:embed black1.png
+var blackPNG []byte
+
+var imgRed, imgWhite, imgBlack image.Image
+
+func init() {
+	imgRed = decode(redPNG)
+	imgWhite = decode(whitePNG)
+	imgBlack = decode(blackPNG)
+}
+
+func decode(b []byte) image.Image {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		panic("Failed to decode image: " + err.Error())
+	}
+	return img
+}
+
+// Piece 返回棋子 p 的贴图
+func Piece(p game.Piece) image.Image {
+	switch p {
+	case game.Red:
+		return imgRed
+	case game.White:
+		return imgWhite
+	default:
+		return imgBlack
+	}
+}
+
+// PiecePNG 返回棋子 p 贴图的原始 PNG 数据（用于 SVG 内嵌）
+func PiecePNG(p game.Piece) []byte {
+	switch p {
+	case game.Red:
+		return redPNG
+	case game.White:
+		return whitePNG
+	default:
+		return blackPNG
+	}
+}
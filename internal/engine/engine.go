@@ -0,0 +1,183 @@
+// File internal/engine/engine.go
+package engine
+
+/*
+基于文本行的引擎协议（类似 UCI），供外部界面或脚本驱动 AI：
+
+	dvonn                          -> id name ... / dvonnok
+	isready                        -> readyok
+	newgame                        重置为开局
+	position startpos [moves ...]  从开局依次执行记谱着法（摆子 "C3"，跳子 "C3-E3"）
+	setoption name <Depth|MultiPV> value <n>
+	go [depth <n>] [multipv <k>]   -> info multipv <i> depth <d> score <s> pv <m1> <m2> ...
+	                               -> bestmove <m> | bestmove none
+	quit
+
+score 为走子方视角；未知命令以 "info string ..." 回报，不中断会话。
+*/
+
+import (
+	"bufio"
+	"dvonn_go/internal/ai"
+	"dvonn_go/internal/game"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultDepth   = 4
+	DefaultMultiPV = 1
+)
+
+// Engine 保存协议会话状态
+type Engine struct {
+	state   game.GameState
+	depth   int
+	multiPV int
+	out     io.Writer
+}
+
+// New 创建一个处于开局局面的引擎会话
+func New(out io.Writer) *Engine {
+	return &Engine{
+		state:   game.StartState(),
+		depth:   DefaultDepth,
+		multiPV: DefaultMultiPV,
+		out:     out,
+	}
+}
+
+// Run 逐行读取命令直到 quit 或输入结束
+func Run(in io.Reader, out io.Writer) error {
+	e := New(out)
+	sc := bufio.NewScanner(in)
+	for sc.Scan() {
+		if !e.Handle(sc.Text()) {
+			return nil
+		}
+	}
+	return sc.Err()
+}
+
+// Handle 处理一行命令；返回 false 表示会话结束
+func (e *Engine) Handle(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return true
+	}
+
+	switch fields[0] {
+	case "dvonn":
+		e.send("id name dvonn_go")
+		e.send("option name Depth type spin default %d", DefaultDepth)
+		e.send("option name MultiPV type spin default %d", DefaultMultiPV)
+		e.send("dvonnok")
+	case "isready":
+		e.send("readyok")
+	case "newgame":
+		e.state = game.StartState()
+	case "position":
+		if err := e.position(fields[1:]); err != nil {
+			e.send("info string %v", err)
+		}
+	case "setoption":
+		if err := e.setOption(fields[1:]); err != nil {
+			e.send("info string %v", err)
+		}
+	case "go":
+		e.goSearch(fields[1:])
+	case "quit":
+		return false
+	default:
+		e.send("info string unknown command %q", fields[0])
+	}
+	return true
+}
+
+// position startpos [moves m1 m2 ...]
+func (e *Engine) position(args []string) error {
+	if len(args) == 0 || args[0] != "startpos" {
+		return fmt.Errorf("position: expected startpos")
+	}
+	gs := game.StartState()
+	if len(args) > 1 {
+		if args[1] != "moves" {
+			return fmt.Errorf("position: expected moves, got %q", args[1])
+		}
+		for _, s := range args[2:] {
+			mv, err := game.ParseMove(s, &gs)
+			if err != nil {
+				return fmt.Errorf("position: %q: %w", s, err)
+			}
+			if err := game.Play(&gs, mv); err != nil {
+				return fmt.Errorf("position: %q: %w", s, err)
+			}
+		}
+	}
+	e.state = gs
+	return nil
+}
+
+// setoption name <Depth|MultiPV> value <n>
+func (e *Engine) setOption(args []string) error {
+	if len(args) != 4 || args[0] != "name" || args[2] != "value" {
+		return fmt.Errorf("setoption: expected name <id> value <n>")
+	}
+	n, err := strconv.Atoi(args[3])
+	if err != nil || n < 1 {
+		return fmt.Errorf("setoption: bad value %q", args[3])
+	}
+	switch strings.ToLower(args[1]) {
+	case "depth":
+		e.depth = n
+	case "multipv":
+		e.multiPV = n
+	default:
+		return fmt.Errorf("setoption: unknown option %q", args[1])
+	}
+	return nil
+}
+
+// go [depth n] [multipv k]
+func (e *Engine) goSearch(args []string) {
+	opts := ai.SearchOptions{Depth: e.depth, MultiPV: e.multiPV}
+	for i := 0; i+1 < len(args); i += 2 {
+		n, err := strconv.Atoi(args[i+1])
+		if err != nil || n < 1 {
+			e.send("info string go: bad value %q", args[i+1])
+			continue
+		}
+		switch args[i] {
+		case "depth":
+			opts.Depth = n
+		case "multipv":
+			opts.MultiPV = n
+		}
+	}
+
+	if e.state.Phase != game.Phase2 {
+		e.send("info string search is only available in the movement phase")
+		e.send("bestmove none")
+		return
+	}
+
+	lines := ai.Search(&e.state, opts)
+	if len(lines) == 0 {
+		e.send("bestmove none")
+		return
+	}
+	for i, l := range lines {
+		pv := make([]string, len(l.PV))
+		for j, m := range l.PV {
+			pv[j] = game.FormatMove(m)
+		}
+		e.send("info multipv %d depth %d score %d pv %s", i+1, l.Depth, l.Score, strings.Join(pv, " "))
+	}
+	e.send("bestmove %s", game.FormatMove(lines[0].Move))
+}
+
+func (e *Engine) send(format string, args ...any) {
+	fmt.Fprintf(e.out, format+"\n", args...)
+}
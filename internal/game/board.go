@@ -285,15 +285,34 @@ func discard(b *Board, coords map[Coordinate]struct{}) *Board {
 	return b
 }
 
-// cleanup: 移除所有与红子断开的连通块
-func cleanup(b *Board) *Board {
+// RemovedComponent 一个因与红子断开而被移除的连通块：各格上被移除的堆（自顶向下）
+type RemovedComponent map[Coordinate]Stack
+
+// Pieces 返回连通块中的棋子总数
+func (c RemovedComponent) Pieces() int {
+	n := 0
+	for _, st := range c {
+		n += len(st)
+	}
+	return n
+}
+
+// cleanup: 移除所有与红子断开的连通块，返回被移除的连通块
+func cleanup(b *Board) []RemovedComponent {
+	var removed []RemovedComponent
 	for _, comp := range allComponents(b) {
 		if !hasRed(b, comp) {
+			rc := make(RemovedComponent, len(comp))
+			for c := range comp {
+				rc[c] = append(Stack(nil), *b.Cells[c.X][c.Y]...)
+			}
 			discard(b, comp)
+			removed = append(removed, rc)
 		}
 	}
-	return b
+	return removed
 }
+
 func allComponents(b *Board) []map[Coordinate]struct{} {
 	visited := make(map[Coordinate]struct{}) // 用来记录已访问过的格子
 	var comps []map[Coordinate]struct{}      // 存储所有的连通块
@@ -323,12 +342,19 @@ func allComponents(b *Board) []map[Coordinate]struct{} {
 // -----------------------------------------------------------------------------
 // 约定：调用者已用 ValidMove 判断合法性；这里不再做额外校验。
 func Apply(m Move, b *Board) Board {
+	nb, _ := ApplyReport(m, b)
+	return nb
+}
+
+// ApplyReport 同 Apply，另外返回这一步清理掉的连通块（摆子时为空）
+func ApplyReport(m Move, b *Board) (Board, []RemovedComponent) {
+	var removed []RemovedComponent
 	switch mv := m.(type) {
 	case JumpMove:
 		// ① 把 mv.From 叠到 mv.To
 		combine(b, mv.From, mv.To)
 		// ② 移除与红子断开的连通块
-		cleanup(b)
+		removed = cleanup(b)
 
 	case PlaceMove:
 		// 直接在目标顶端放入棋子
@@ -339,7 +365,7 @@ func Apply(m Move, b *Board) Board {
 	}
 
 	// 返回修改后的「值拷贝」，方便写：gs.Board = Apply(mv, &gs.Board)
-	return *b
+	return *b, removed
 }
 
 // Clone 深度拷贝一个 Board，Cells map 与 Discard 切片都新建
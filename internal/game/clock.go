@@ -0,0 +1,237 @@
+// File internal/game/clock.go
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+对局计时，三种用时规则：
+  - 包干（sudden death）："5m"，用完即负
+  - Fischer 加秒："5m+3s"，每走一步加 3 秒
+  - 读秒（byo-yomi）："10m/3x30s"，基本用时用完后有 3 次 30 秒读秒，
+    在一次读秒内走完则该次不消耗，每超出一整次读秒扣一次，扣完即负
+计时只按传入的 now 计算，不自己读系统时间。
+*/
+
+// TimeControl 用时规则；零值表示不计时
+type TimeControl struct {
+	Base      time.Duration // 基本用时
+	Increment time.Duration // Fischer 每步加秒
+	Period    time.Duration // 每次读秒的时长
+	Periods   int           // 读秒次数
+}
+
+// TimeControlError 用时规则无法解析
+var TimeControlError = errors.New("time control: want e.g. 5m, 5m+3s or 10m/3x30s")
+
+// ParseTimeControl 解析 "5m"、"5m+3s"、"10m/3x30s"；空串或 "off" 返回零值
+func ParseTimeControl(s string) (TimeControl, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var tc TimeControl
+	if s == "" || s == "off" {
+		return tc, nil
+	}
+	base, inc, _ := strings.Cut(s, "+")
+	if inc != "" {
+		d, err := time.ParseDuration(inc)
+		if err != nil || d <= 0 {
+			return TimeControl{}, TimeControlError
+		}
+		tc.Increment = d
+	}
+	base, byo, ok := strings.Cut(base, "/")
+	if ok {
+		n, period, found := strings.Cut(byo, "x")
+		periods, err := strconv.Atoi(n)
+		d, err2 := time.ParseDuration(period)
+		if !found || err != nil || err2 != nil || periods <= 0 || d <= 0 || tc.Increment > 0 {
+			return TimeControl{}, TimeControlError
+		}
+		tc.Period, tc.Periods = d, periods
+	}
+	d, err := time.ParseDuration(base)
+	if err != nil || d <= 0 {
+		return TimeControl{}, TimeControlError
+	}
+	tc.Base = d
+	return tc, nil
+}
+
+// IsZero 不计时
+func (tc TimeControl) IsZero() bool { return tc.Base == 0 }
+
+// String 与 ParseTimeControl 互逆
+func (tc TimeControl) String() string {
+	switch {
+	case tc.IsZero():
+		return "off"
+	case tc.Increment > 0:
+		return shortDuration(tc.Base) + "+" + shortDuration(tc.Increment)
+	case tc.Periods > 0:
+		return fmt.Sprintf("%s/%dx%s", shortDuration(tc.Base), tc.Periods, shortDuration(tc.Period))
+	default:
+		return shortDuration(tc.Base)
+	}
+}
+
+// Kind 规则名称：sudden death / Fischer / byo-yomi
+func (tc TimeControl) Kind() string {
+	switch {
+	case tc.Increment > 0:
+		return "Fischer"
+	case tc.Periods > 0:
+		return "byo-yomi"
+	default:
+		return "sudden death"
+	}
+}
+
+// shortDuration 去掉 time.Duration.String 末尾多余的 "0s" / "0m"，如 5m0s -> 5m
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// Clock 双方的棋钟，按 Player 下标
+type Clock struct {
+	TC      TimeControl
+	Left    [2]time.Duration // 剩余基本用时；读秒阶段为 0
+	Periods [2]int           // 剩余读秒次数
+	Flagged *Player          // 超时判负的一方；nil 表示未超时
+
+	side    Player // 正在走的一方
+	running bool
+	since   time.Time     // 本段计时的起点
+	used    time.Duration // 本步此前已用的时间（暂停过的部分）
+}
+
+// NewClock 按 tc 为双方设好用时
+func NewClock(tc TimeControl) *Clock {
+	c := &Clock{TC: tc}
+	for p := range c.Left {
+		c.Left[p], c.Periods[p] = tc.Base, tc.Periods
+	}
+	return c
+}
+
+// Start 开始为 p 计时；p 的本步从头算起，除非是暂停后继续同一方
+func (c *Clock) Start(p Player, now time.Time) {
+	if c.running || c.Flagged != nil {
+		return
+	}
+	if p != c.side {
+		c.used = 0
+	}
+	c.side, c.running, c.since = p, true, now
+}
+
+// Running 是否在计时；side 为正在走的一方
+func (c *Clock) Running() (side Player, running bool) {
+	return c.side, c.running
+}
+
+// Pause 暂停计时（如回到菜单），本步已用的时间保留
+func (c *Clock) Pause(now time.Time) {
+	if !c.running {
+		return
+	}
+	c.used += now.Sub(c.since)
+	c.running = false
+}
+
+// Press 走完一步：扣除本步用时并按规则加秒 / 消耗读秒，停止计时，返回本步戳记。
+// 超时时记下 Flagged。
+func (c *Clock) Press(now time.Time) ClockStamp {
+	c.Pause(now)
+	p := c.side
+	left, periods, _, flagged := c.charge(p, c.used)
+	if !flagged {
+		left += c.TC.Increment
+	}
+	c.Left[p], c.Periods[p] = left, periods
+	if flagged {
+		c.flag(p)
+	}
+	stamp := ClockStamp{UsedMs: c.used.Milliseconds(), LeftMs: left.Milliseconds(), Periods: periods}
+	c.used = 0
+	return stamp
+}
+
+// Expired 检查正在走的一方是否已超时，超时则记下 Flagged
+func (c *Clock) Expired(now time.Time) bool {
+	if c.Flagged != nil {
+		return true
+	}
+	if !c.running {
+		return false
+	}
+	if _, _, _, flagged := c.charge(c.side, c.used+now.Sub(c.since)); flagged {
+		c.flag(c.side)
+		c.running = false
+	}
+	return c.Flagged != nil
+}
+
+// Remaining p 此刻的剩余基本用时、剩余读秒次数与当前这次读秒的剩余时间（显示用，不改变棋钟）
+func (c *Clock) Remaining(p Player, now time.Time) (left time.Duration, periods int, period time.Duration) {
+	var used time.Duration
+	if p == c.side {
+		used = c.used
+		if c.running {
+			used += now.Sub(c.since)
+		}
+	}
+	left, periods, period, _ = c.charge(p, used)
+	return
+}
+
+// charge 计算 p 再用掉 used 之后的剩余时间
+func (c *Clock) charge(p Player, used time.Duration) (left time.Duration, periods int, period time.Duration, flagged bool) {
+	left, periods = c.Left[p], c.Periods[p]
+	if used < left {
+		return left - used, periods, c.TC.Period, false
+	}
+	over := used - left
+	if c.TC.Periods == 0 || periods == 0 {
+		return 0, periods, 0, true
+	}
+	spent := int(over / c.TC.Period)
+	if spent >= periods {
+		return 0, 0, 0, true
+	}
+	return 0, periods - spent, c.TC.Period - over%c.TC.Period, false
+}
+
+func (c *Clock) flag(p Player) {
+	c.Flagged = &p
+}
+
+// ClockStamp 记录中一步的计时：本步用时与走完后的剩余
+type ClockStamp struct {
+	UsedMs  int64 `json:"used_ms"`
+	LeftMs  int64 `json:"left_ms"`
+	Periods int   `json:"periods,omitempty"`
+}
+
+// FormatClock 以 m:ss 显示，不足 10 秒时带十分之一秒
+func FormatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	if d < 10*time.Second {
+		return fmt.Sprintf("0:%04.1f", d.Seconds())
+	}
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
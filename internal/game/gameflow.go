@@ -2,11 +2,8 @@
 package game
 
 import (
-	"bufio"
 	"fmt"
 	"math/rand"
-	"os"
-	"strings"
 )
 
 var order = []Piece{Red, Red, Red}
@@ -37,72 +34,6 @@ func StartState() GameState {
 	}
 }
 
-func prompt(t TurnState) string {
-	switch t {
-	case PlacingRed:
-		return "Place a red piece"
-	case PlacingWhite:
-		return "Place a white piece"
-	case PlacingBlack:
-		return "Place a black piece"
-	case MoveWhite:
-		return "White move"
-	case MoveBlack:
-		return "Black move"
-	default:
-		return "UnknownTurnState"
-	}
-}
-
-func readLine() string {
-	r := bufio.NewReader(os.Stdin)
-	s, _ := r.ReadString('\n')
-	return strings.TrimSpace(s)
-}
-
-func printErr(e GameError) {
-	switch e {
-	case MoveParseError:
-		fmt.Println("You entered a malformed move.")
-	case InvalidMove:
-		fmt.Println("Your move was incorrect.")
-	default:
-		fmt.Println("Unknown error.")
-	}
-}
-func getTurnInput(gs *GameState) Move {
-	// We don't use text input in the GUI version; all moves
-	// are generated by mouse events in the Ebiten layer.
-	return nil
-}
-
-// -----------------------------------------------------------------------------
-// 执行走子
-// -----------------------------------------------------------------------------
-//func ExecuteMove(gs *GameState, mv Move) {
-//	// 验证走子是否合法
-//	if !ValidMove(&gs.Board, mv) {
-//		printErr(InvalidMove)
-//		return
-//	}
-//
-//	// 应用走子，返回新的棋盘
-//	gs.Board = Apply(mv, &gs.Board)
-//
-//	switch m := mv.(type) {
-//	case JumpMove:
-//		// 更新下一回合
-//		gs.Turn = GetNextTurn(&gs.Board, m.Player)
-//	case PlaceMove:
-//		// 放子阶段：由 runPlacementPhase 控制顺序，不在这里更新
-//	}
-//
-//	// 检查是否有合法的跳子，如果没有合法跳子则结束回合
-//	if !HasAnyLegalMoves(&gs.Board, gs.Turn) {
-//		gs.Turn = End
-//	}
-//}
-
 // -----------------------------------------------------------------------------
 // Phase 1 — 放子
 // -----------------------------------------------------------------------------
@@ -166,6 +97,11 @@ func findEmptySpot(b *Board) (int, int) {
 // Phase 2 — 跳子
 // -----------------------------------------------------------------------------
 func RunMovementPhase(gs *GameState, from, to Coordinate) {
+	runMovement(gs, from, to)
+}
+
+// runMovement 执行跳子并返回被清理掉的连通块
+func runMovement(gs *GameState, from, to Coordinate) []RemovedComponent {
 	// 当前行动方
 	pl := TurnStateToPlayer(gs.Turn)
 
@@ -174,12 +110,15 @@ func RunMovementPhase(gs *GameState, from, to Coordinate) {
 
 	// 合法性校验
 	if !ValidMove(&gs.Board, mv) {
-		fmt.Printf("Invalid move: player=%v, from stack=%v\n", mv.Player, gs.Board.Cells[mv.From.X][mv.From.Y])
-		return
+		fmt.Printf("Invalid move %s by %v (from stack %v):\n%s", FormatMove(mv), mv.Player,
+			gs.Board.Cells[mv.From.X][mv.From.Y],
+			gs.Board.Render(TextOptions{Labels: true, Highlight: []Coordinate{mv.From, mv.To}}))
+		return nil
 	}
 
 	// 应用跳子（combine+cleanup 都在 Apply 里完成）
-	gs.Board = Apply(mv, &gs.Board)
+	var removed []RemovedComponent
+	gs.Board, removed = ApplyReport(mv, &gs.Board)
 
 	// 更新下一手
 	gs.Turn = GetNextTurn(&gs.Board, pl)
@@ -188,7 +127,79 @@ func RunMovementPhase(gs *GameState, from, to Coordinate) {
 	if !HasAnyLegalMoves(&gs.Board, gs.Turn) {
 		gs.Turn = End
 	}
+	return removed
+}
+
+// Play 校验并执行一步（摆子或跳子）；非法时返回 InvalidMove 且局面不变
+func Play(gs *GameState, mv Move) error {
+	_, err := PlayReport(gs, mv)
+	return err
+}
+
+// PlayReport 同 Play，另外返回这一步清理掉的连通块
+func PlayReport(gs *GameState, mv Move) ([]RemovedComponent, error) {
+	var removed []RemovedComponent
+	switch m := mv.(type) {
+	case PlaceMove:
+		if gs.Phase != Phase1 || m.Piece != NextPiece(gs) ||
+			!IsPlayable(m.At) || nonempty(&gs.Board, m.At) {
+			return nil, InvalidMove
+		}
+		RunPlacementPhase(gs, m.At.X, m.At.Y)
+	case JumpMove:
+		if gs.Phase != Phase2 || IsGameOver(gs) ||
+			m.Player != TurnStateToPlayer(gs.Turn) || !ValidMove(&gs.Board, m) {
+			return nil, InvalidMove
+		}
+		removed = runMovement(gs, m.From, m.To)
+	default:
+		return nil, InvalidMove
+	}
+	return removed, nil
+}
+
+// PlacingPlayer 返回摆子阶段轮到的玩家：白方先摆，双方交替（红子也轮流摆放）
+func PlacingPlayer(gs *GameState) Player {
+	if gs.PlaceStep%2 == 0 {
+		return PWhite
+	}
+	return PBlack
+}
+
+// ToMove 返回当前应行动的一方：摆子阶段按 PlacingPlayer，跳子阶段按 Turn
+func ToMove(gs *GameState) Player {
+	if gs.Phase == Phase1 {
+		return PlacingPlayer(gs)
+	}
+	return TurnStateToPlayer(gs.Turn)
+}
+
+// NextPiece 返回摆子阶段下一枚要放的棋子颜色；摆满后返回 Red 作占位
+func NextPiece(gs *GameState) Piece {
+	if gs.PlaceStep < 0 || gs.PlaceStep >= totalPieceNum {
+		return Red
+	}
+	return order[gs.PlaceStep]
 }
+
+// RemainingPieces 返回摆子阶段尚未放上棋盘的红、白、黑子数
+func RemainingPieces(gs *GameState) (red, white, black int) {
+	for i := gs.PlaceStep; i < totalPieceNum; i++ {
+		if i < 0 {
+			continue
+		}
+		switch order[i] {
+		case Red:
+			red++
+		case White:
+			white++
+		case Black:
+			black++
+		}
+	}
+	return
+}
+
 func TurnStateToPlayer(ts TurnState) Player {
 	switch ts {
 	case MoveWhite, PlacingWhite:
@@ -211,22 +222,3 @@ func IsGameOver(gs *GameState) bool {
 func Winner(gs *GameState) *Player {
 	return calcWinner(&gs.Board) // 已在 board.go 实现
 }
-
-var summary1 = `Welcome to DVONN.
-
-In Phase 1, you must place your pieces one by one on
-unoccupied spaces on the game board. White will begin
-and players will alternate placing. Place your red
-pieces first, and then proceed to place your normal pieces.
-White has two red pieces and Black has one red piece.
-
-To place a piece, use the command <coord>, for example, "A3".`
-
-var summary2 = `
-In Phase 2, you may move any stack of height n with your color
-piece on top to be on top of another stack that is exactly
-n hops away. Be careful, though! Any stacks that are not
-in a connected component with a red piece will be discarded.
-Whoever controls the most pieces at the end wins!
-
-To jump, use the command <start> "to" <end>, for example "A1 to A3".`
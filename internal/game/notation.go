@@ -0,0 +1,84 @@
+// File internal/game/notation.go
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+标准 DVONN 记谱：
+  - 行号 1–5 自上而下
+  - 列字母 A–K 沿斜线自左向右（第 1 行为 A–I，第 5 行为 C–K）
+  - 摆子写作 "C3"，跳子写作 "C3-E3"（解析时也接受 "C3 E3"、"C3 to E3"）
+*/
+
+// FormatCoord 把内部坐标转成标准记谱，如 "C3"；棋盘外坐标退回 Coordinate.String()
+func FormatCoord(c Coordinate) string {
+	if !IsPlayable(c) {
+		return c.String()
+	}
+	q, r := indexToAxial(c)
+	col := 'A' + rune(q+r-axialMinQ)
+	row := r - axialMinR + 1
+	return fmt.Sprintf("%c%d", col, row)
+}
+
+// FormatMove 把走子转成标准记谱
+func FormatMove(m Move) string {
+	switch mv := m.(type) {
+	case PlaceMove:
+		return FormatCoord(mv.At)
+	case JumpMove:
+		return FormatCoord(mv.From) + "-" + FormatCoord(mv.To)
+	default:
+		return "?"
+	}
+}
+
+// ParseCoord 解析标准记谱坐标（大小写不敏感），非法时返回 MoveParseError
+func ParseCoord(s string) (Coordinate, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) != 2 || s[0] < 'A' || s[0] > 'K' || s[1] < '1' || s[1] > '5' {
+		return Coordinate{}, MoveParseError
+	}
+	r := int(s[1]-'1') + axialMinR
+	q := int(s[0]-'A') + axialMinQ - r
+	c, ok := IndexFromAxial(q, r)
+	if !ok {
+		return Coordinate{}, MoveParseError
+	}
+	return c, nil
+}
+
+// ParseMove 按当前局面解析一步棋：摆子阶段为单个坐标，跳子阶段为起止两个坐标。
+// 只做语法解析，合法性仍由 ValidMove / Play 判定。
+func ParseMove(s string, gs *GameState) (Move, error) {
+	fields := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return r == '-' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 3 && fields[1] == "TO" {
+		fields = []string{fields[0], fields[2]}
+	}
+
+	switch len(fields) {
+	case 1:
+		at, err := ParseCoord(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		return PlaceMove{Piece: NextPiece(gs), At: at}, nil
+	case 2:
+		from, err := ParseCoord(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := ParseCoord(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		return JumpMove{Player: TurnStateToPlayer(gs.Turn), From: from, To: to}, nil
+	default:
+		return nil, MoveParseError
+	}
+}
@@ -0,0 +1,156 @@
+// File internal/game/position.go
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+局面串：一行文本完整描述一个局面，便于在文档、bug 报告与命令行中传递。
+
+	<棋盘> <阶段> <行动方> <弃子>
+
+  - 棋盘：自上而下 5 行以 "/" 分隔，行内各格自左向右以 "," 分隔；
+    每格为自顶向下的棋子首字母（如 "WBR"），空格为 "."
+  - 阶段：1 摆子 / 2 跳子
+  - 行动方：w / b，终局为 "-"（摆子阶段由已摆子数推出，仅作提示）
+  - 弃子：被移除棋子的首字母，没有为 "-"
+
+例（摆了三枚红子与一枚黑子）：
+
+	R,R,R,B,.,.,.,.,./.,.,.,.,.,.,.,.,.,./.,.,.,.,.,.,.,.,.,.,./.,.,.,.,.,.,.,.,.,./.,.,.,.,.,.,.,.,. 1 w -
+*/
+
+// FormatPosition 返回 gs 的局面串
+func FormatPosition(gs *GameState) string {
+	rows := make([]string, 0, BoardHeight)
+	for _, cells := range textRows() {
+		parts := make([]string, len(cells))
+		for i, c := range cells {
+			parts[i] = "."
+			if st := gs.Board.Cells[c.X][c.Y]; st != nil && len(*st) > 0 {
+				parts[i] = st.String()
+			}
+		}
+		rows = append(rows, strings.Join(parts, ","))
+	}
+
+	turn := "-"
+	if !IsGameOver(gs) {
+		turn = strings.ToLower(ToMove(gs).String()[:1])
+	}
+	discard := "-"
+	if len(gs.Board.Discard) > 0 {
+		discard = gs.Board.Discard.String()
+	}
+	return fmt.Sprintf("%s %d %s %s", strings.Join(rows, "/"), int(gs.Phase)+1, turn, discard)
+}
+
+// ParsePosition 解析局面串。摆子阶段要求棋盘上的棋子恰好是摆子顺序的一个前缀。
+func ParsePosition(s string) (GameState, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 4 {
+		return GameState{}, fmt.Errorf("position: want 4 fields (board phase turn discard), got %d", len(fields))
+	}
+
+	gs := GameState{Board: EmptyDvonn}
+	rows := strings.Split(fields[0], "/")
+	layout := textRows()
+	if len(rows) != len(layout) {
+		return GameState{}, fmt.Errorf("position: want %d rows, got %d", len(layout), len(rows))
+	}
+	placed := 0
+	for y, row := range rows {
+		cells := strings.Split(row, ",")
+		if len(cells) != len(layout[y]) {
+			return GameState{}, fmt.Errorf("position: row %d: want %d cells, got %d", y+1, len(layout[y]), len(cells))
+		}
+		for i, cell := range cells {
+			if cell == "." {
+				continue
+			}
+			st, err := parseStack(cell)
+			if err != nil {
+				return GameState{}, fmt.Errorf("position: row %d cell %d: %w", y+1, i+1, err)
+			}
+			c := layout[y][i]
+			gs.Board.Cells[c.X][c.Y] = &st
+			placed += len(st)
+		}
+	}
+
+	if fields[3] != "-" {
+		st, err := parseStack(fields[3])
+		if err != nil {
+			return GameState{}, fmt.Errorf("position: discard: %w", err)
+		}
+		gs.Board.Discard = st
+	}
+
+	switch fields[1] {
+	case "1":
+		gs.Phase, gs.Turn, gs.PlaceStep = Phase1, PlacingRed, int64(placed)
+		if err := checkPlacementPrefix(&gs.Board, placed); err != nil {
+			return GameState{}, err
+		}
+	case "2":
+		gs.Phase, gs.PlaceStep = Phase2, totalPieceNum
+		switch fields[2] {
+		case "w":
+			gs.Turn = MoveWhite
+		case "b":
+			gs.Turn = MoveBlack
+		case "-":
+			gs.Turn = End
+		default:
+			return GameState{}, fmt.Errorf("position: unknown side to move %q", fields[2])
+		}
+	default:
+		return GameState{}, fmt.Errorf("position: unknown phase %q", fields[1])
+	}
+	return gs, nil
+}
+
+// parseStack 把 "WBR" 这样的首字母串还原为棋子堆
+func parseStack(s string) (Stack, error) {
+	st := make(Stack, 0, len(s))
+	for _, r := range strings.ToUpper(s) {
+		switch r {
+		case 'R':
+			st = append(st, Red)
+		case 'W':
+			st = append(st, White)
+		case 'B':
+			st = append(st, Black)
+		default:
+			return nil, fmt.Errorf("unknown piece %q", r)
+		}
+	}
+	return st, nil
+}
+
+// checkPlacementPrefix 摆子阶段每格只有一枚棋子，且各色数目与前 placed 步摆子顺序一致
+func checkPlacementPrefix(b *Board, placed int) error {
+	if placed >= totalPieceNum {
+		return fmt.Errorf("position: %d pieces placed, placement phase ends at %d", placed, totalPieceNum)
+	}
+	var want, got [3]int
+	for _, p := range order[:placed] {
+		want[p]++
+	}
+	for _, c := range playableCoords {
+		st := b.Cells[c.X][c.Y]
+		if st == nil || len(*st) == 0 {
+			continue
+		}
+		if len(*st) > 1 {
+			return fmt.Errorf("position: stack at %s during placement", FormatCoord(c))
+		}
+		got[(*st)[0]]++
+	}
+	if want != got {
+		return fmt.Errorf("position: piece counts %v do not match placement order %v (red, white, black)", got, want)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// File internal/game/preview.go
+package game
+
+// Preview 一步着法的预演结果，用于在落子前展示其后果
+type Preview struct {
+	After GameState // 着法执行后的局面
+
+	// Discarded 将因与 DVONN 子断开而被移除的堆，键为跳子合并后、清理前的位置；
+	// 跳到断开区域时终点（合并后的堆）也在其中
+	Discarded map[Coordinate]Stack
+
+	// Delta 双方控制棋子数（堆顶为本方的堆高之和）的变化，按 Player 下标
+	Delta [2]int
+}
+
+// Removed 返回将被移除的棋子总数
+func (p *Preview) Removed() int {
+	n := 0
+	for _, st := range p.Discarded {
+		n += len(st)
+	}
+	return n
+}
+
+// PreviewMove 在副本上执行 mv 并报告其后果，gs 保持不变；mv 非法时返回 InvalidMove
+func PreviewMove(gs *GameState, mv Move) (Preview, error) {
+	after := *gs
+	after.Board = gs.Board.Clone()
+	removed, err := PlayReport(&after, mv)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	p := Preview{After: after, Discarded: map[Coordinate]Stack{}}
+	for _, comp := range removed {
+		for c, st := range comp {
+			p.Discarded[c] = st
+		}
+	}
+
+	before, now := controlled(&gs.Board), controlled(&after.Board)
+	for i := range p.Delta {
+		p.Delta[i] = now[i] - before[i]
+	}
+	return p, nil
+}
+
+// controlled 统计双方控制的棋子数，按 Player 下标
+func controlled(b *Board) [2]int {
+	var n [2]int
+	for _, c := range playableCoords {
+		st := innerstack(b, c)
+		if len(st) == 0 {
+			continue
+		}
+		switch st[0] {
+		case White:
+			n[PWhite] += len(st)
+		case Black:
+			n[PBlack] += len(st)
+		}
+	}
+	return n
+}
@@ -0,0 +1,177 @@
+// File internal/game/record.go
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Record 一局棋的完整记录（着法用标准记谱），可保存为 JSON
+type Record struct {
+	Mode        string        `json:"mode,omitempty"`
+	Start       string        `json:"start,omitempty"`        // 不从开局开始时的起始局面串（如编辑器摆出的局面）
+	TimeControl string        `json:"time_control,omitempty"` // 见 ParseTimeControl
+	Result      string        `json:"result,omitempty"`       // 终局结果，如超时判负
+	Moves       []RecordEntry `json:"moves"`
+	Hints       [2]int        `json:"hints"` // 按 Player 下标统计的提示次数
+}
+
+// RecordEntry 记录中的一步；复盘后附带评注
+type RecordEntry struct {
+	Move       string `json:"move"`
+	Annotation string `json:"annotation,omitempty"` // best / good / inaccuracy / blunder
+	Best       string `json:"best,omitempty"`       // 引擎推荐着法
+	Loss       int    `json:"loss,omitempty"`       // 相对最佳着法的失分
+
+	Clock *ClockStamp `json:"clock,omitempty"` // 计时对局中本步的用时与剩余
+}
+
+// NewRecord 为 gs 开始一份记录。若 gs 已有摆子（如 FillPhase1Auto），
+// 按摆子顺序还原出一串等价的摆子着法，使记录总能从开局重放；
+// 无法还原时（如编辑器摆出的局面）记下起始局面串。
+func NewRecord(gs *GameState, mode string) *Record {
+	r := &Record{Mode: mode}
+	moves := placementsFor(gs)
+	if moves != nil && !sameTurnAfter(moves, gs.Turn) {
+		moves = nil // 编辑器改过行棋方
+	}
+	if moves == nil && gs.PlaceStep > 0 {
+		r.Start = FormatPosition(gs)
+	}
+	for _, m := range moves {
+		r.Add(m)
+	}
+	return r
+}
+
+// Add 追加一步已执行的着法
+func (r *Record) Add(m Move) {
+	r.Moves = append(r.Moves, RecordEntry{Move: FormatMove(m)})
+}
+
+// Stamp 为最后一步记下计时
+func (r *Record) Stamp(s ClockStamp) {
+	if len(r.Moves) > 0 {
+		r.Moves[len(r.Moves)-1].Clock = &s
+	}
+}
+
+// ResumeClock 按记录的用时规则与各方最后一次计时恢复棋钟；不计时的记录返回 nil。
+// states 为 Replay 的结果，用来判断每步是谁走的。
+func (r *Record) ResumeClock(states []GameState) (*Clock, error) {
+	tc, err := ParseTimeControl(r.TimeControl)
+	if err != nil || tc.IsZero() {
+		return nil, err
+	}
+	c := NewClock(tc)
+	for i, e := range r.Moves {
+		if e.Clock == nil || i >= len(states) {
+			continue
+		}
+		p := ToMove(&states[i])
+		c.Left[p] = time.Duration(e.Clock.LeftMs) * time.Millisecond
+		c.Periods[p] = e.Clock.Periods
+	}
+	return c, nil
+}
+
+// AddHint 为玩家 p 记一次提示
+func (r *Record) AddHint(p Player) {
+	r.Hints[p]++
+}
+
+// Replay 从开局依次执行记录中的着法。states[i] 为第 i 步之前的局面，
+// 末尾多一个终局局面；moves[i] 为解析后的第 i 步。
+func (r *Record) Replay() (states []GameState, moves []Move, err error) {
+	gs := StartState()
+	if r.Start != "" {
+		if gs, err = ParsePosition(r.Start); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, e := range r.Moves {
+		mv, err := ParseMove(e.Move, &gs)
+		if err != nil {
+			return states, moves, err
+		}
+		snap := gs
+		snap.Board = gs.Board.Clone()
+		states = append(states, snap)
+		if err := Play(&gs, mv); err != nil {
+			return states, moves, err
+		}
+		moves = append(moves, mv)
+	}
+	return append(states, gs), moves, nil
+}
+
+// Save 以缩进 JSON 写入文件
+func (r *Record) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadRecord 从 JSON 文件读取记录
+func LoadRecord(path string) (*Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// sameTurnAfter 从开局执行 moves 后是否轮到 turn
+func sameTurnAfter(moves []PlaceMove, turn TurnState) bool {
+	gs := StartState()
+	for _, m := range moves {
+		if Play(&gs, m) != nil {
+			return false
+		}
+	}
+	return gs.Turn == turn
+}
+
+// placementsFor 按 order 的颜色顺序为盘面上已摆的单子生成摆子序列；
+// 盘面已进入跳子（有叠子或弃子）时无法还原，返回 nil
+func placementsFor(gs *GameState) []PlaceMove {
+	if gs.PlaceStep == 0 || len(gs.Board.Discard) > 0 {
+		return nil
+	}
+
+	byPiece := map[Piece][]Coordinate{}
+	count := 0
+	for _, c := range playableCoords {
+		st := innerstack(&gs.Board, c)
+		switch len(st) {
+		case 0:
+			continue
+		case 1:
+			byPiece[st[0]] = append(byPiece[st[0]], c)
+			count++
+		default:
+			return nil
+		}
+	}
+	if int64(count) != gs.PlaceStep {
+		return nil
+	}
+
+	moves := make([]PlaceMove, 0, count)
+	for step := int64(0); step < gs.PlaceStep; step++ {
+		p := order[step]
+		if len(byPiece[p]) == 0 {
+			return nil
+		}
+		moves = append(moves, PlaceMove{Piece: p, At: byPiece[p][0]})
+		byPiece[p] = byPiece[p][1:]
+	}
+	return moves
+}
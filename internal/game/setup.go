@@ -0,0 +1,115 @@
+// File internal/game/setup.go
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 一局棋的棋子总数，按 Piece 下标（红、白、黑）
+var pieceTotals = [3]int{3, 23, 23}
+
+// SetupPosition 由任意摆好的棋盘构造局面，用于局面编辑器。
+//   - 摆子阶段：棋盘上的棋子须是摆子顺序的一个前缀，弃子区清空，side 被忽略
+//   - 跳子阶段：不在棋盘上的棋子都算作弃子；side 无子可走时轮到对方，双方都无子可走则终局
+//
+// 返回的错误列出所有不合规之处（errors.Join），此时局面仍按上述规则构造好，可供显示。
+func SetupPosition(b Board, phase GamePhase, side Player) (GameState, error) {
+	gs := GameState{Board: b.Clone(), Phase: phase}
+	gs.Board.Discard = nil
+
+	var onBoard [3]int
+	placed := 0
+	for _, c := range playableCoords {
+		for _, p := range innerstack(&gs.Board, c) {
+			onBoard[p]++
+			placed++
+		}
+	}
+
+	var errs []error
+	for p, n := range onBoard {
+		if n > pieceTotals[p] {
+			errs = append(errs, fmt.Errorf("%d %s pieces on the board, at most %d", n, Piece(p), pieceTotals[p]))
+		}
+	}
+
+	if phase == Phase1 {
+		gs.Turn, gs.PlaceStep = PlacingRed, int64(placed)
+		if err := checkPlacementPrefix(&gs.Board, placed); err != nil {
+			errs = append(errs, err)
+		}
+		return gs, errors.Join(errs...)
+	}
+
+	gs.PlaceStep = totalPieceNum
+	for p, n := range onBoard {
+		for i := n; i < pieceTotals[p]; i++ {
+			gs.Board.Discard = append(gs.Board.Discard, Piece(p))
+		}
+	}
+	if placed > 0 && onBoard[Red] == 0 {
+		errs = append(errs, errors.New("no DVONN pieces on the board"))
+	}
+	for _, comp := range allComponents(&gs.Board) {
+		if onBoard[Red] > 0 && !hasRed(&gs.Board, comp) {
+			for c := range comp {
+				errs = append(errs, fmt.Errorf("stacks around %s are cut off from the DVONN pieces", FormatCoord(c)))
+				break
+			}
+		}
+	}
+	gs.Turn = GetNextTurn(&gs.Board, opponentOf(side))
+	return gs, errors.Join(errs...)
+}
+
+func opponentOf(p Player) Player {
+	if p == PWhite {
+		return PBlack
+	}
+	return PWhite
+}
+
+// -----------------------------------------------------------------------------
+// 局面编辑器的棋堆修改：空堆上除加子外都原样返回
+// -----------------------------------------------------------------------------
+
+// AddTop 把 p 放到堆顶
+func AddTop(st Stack, p Piece) Stack { return append(Stack{p}, st...) }
+
+// AddBottom 把 p 垫到堆底
+func AddBottom(st Stack, p Piece) Stack { return append(st[:len(st):len(st)], p) }
+
+// RemoveTop 拿掉堆顶的子
+func RemoveTop(st Stack) Stack {
+	if len(st) == 0 {
+		return st
+	}
+	return st[1:]
+}
+
+// TopToBottom 把堆顶的子移到堆底
+func TopToBottom(st Stack) Stack {
+	if len(st) == 0 {
+		return st
+	}
+	return append(append(Stack(nil), st[1:]...), st[0])
+}
+
+// BottomToTop 把堆底的子移到堆顶
+func BottomToTop(st Stack) Stack {
+	if len(st) == 0 {
+		return st
+	}
+	return append(Stack{st[len(st)-1]}, st[:len(st)-1]...)
+}
+
+// EditStack 用 fn 修改 c 格的棋堆（fn 拿到的是副本）；结果为空时清空该格
+func (b *Board) EditStack(c Coordinate, fn func(Stack) Stack) {
+	st := fn(append(Stack(nil), innerstack(b, c)...))
+	if len(st) == 0 {
+		b.Cells[c.X][c.Y] = nil
+	} else {
+		b.Cells[c.X][c.Y] = &st
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import "testing"
+
+func TestStackEdits(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		fn       func(Stack) Stack
+		in, want string
+	}{
+		{"add top", func(st Stack) Stack { return AddTop(st, Red) }, "WB", "RWB"},
+		{"add bottom", func(st Stack) Stack { return AddBottom(st, Red) }, "WB", "WBR"},
+		{"add on empty", func(st Stack) Stack { return AddTop(st, White) }, "", "W"},
+		{"remove top", RemoveTop, "WB", "B"},
+		{"top to bottom", TopToBottom, "RWB", "WBR"},
+		{"bottom to top", BottomToTop, "RWB", "BRW"},
+		// 空堆上的移除 / 换序什么都不做，而不是越界
+		{"remove empty", RemoveTop, "", ""},
+		{"top to bottom empty", TopToBottom, "", ""},
+		{"bottom to top empty", BottomToTop, "", ""},
+	} {
+		in := mustStack(t, tc.in)
+		orig := in.String()
+		if got := tc.fn(in); got.String() != mustStack(t, tc.want).String() {
+			t.Errorf("%s(%s) = %s, want %s", tc.name, tc.in, got, tc.want)
+		}
+		if in.String() != orig {
+			t.Errorf("%s modified its input: %s", tc.name, in)
+		}
+	}
+}
+
+func mustStack(t *testing.T, s string) Stack {
+	t.Helper()
+	st, err := parseStack(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestEditStack(t *testing.T) {
+	b := EmptyDvonn.Clone()
+	c := Coordinate{X: 2, Y: 2}
+
+	b.EditStack(c, RemoveTop)
+	if b.Cells[c.X][c.Y] != nil {
+		t.Fatalf("editing an empty cell left %v", b.Cells[c.X][c.Y])
+	}
+	b.EditStack(c, func(st Stack) Stack { return AddTop(st, White) })
+	if st := innerstack(&b, c); len(st) != 1 || st[0] != White {
+		t.Fatalf("add on empty cell: got %v", st)
+	}
+	b.EditStack(c, RemoveTop)
+	if b.Cells[c.X][c.Y] != nil {
+		t.Fatalf("removing the last piece should clear the cell")
+	}
+}
@@ -0,0 +1,213 @@
+// File internal/game/textboard.go
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+文本棋盘（移植自 console/boardprint.go），用于终端、日志、测试与错误信息：
+
+	        A   B   C
+	      /*\ / \ / \
+	1    |W 3|B 1|   |
+	    / \ / \ / \ / \
+	2  |   |R 1|...
+
+每格 4 列宽，上下相邻行错开半格；格内为「堆顶颜色 + 堆高」，格顶 '*' 表示该堆含红子。
+左侧为行号 1–5，上下为斜列字母 A–K（标准记谱）。高亮格的竖边画成 '#'（Unicode 为 '┃'），
+开启颜色时格内文字反色显示。
+*/
+
+// TextOptions 控制文本棋盘的绘制方式
+type TextOptions struct {
+	Unicode   bool         // 使用 Unicode 线条
+	Color     bool         // 使用 ANSI 颜色
+	Labels    bool         // 画出行号与斜列字母
+	Highlight []Coordinate // 需要高亮的格子
+}
+
+const (
+	textCellWidth = 4
+	textRowPrefix = 3 // 行号列宽
+
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiWhite   = "\x1b[1;97m"
+	ansiBlack   = "\x1b[1;90m"
+	ansiReverse = "\x1b[7m"
+)
+
+// textGlyphs 一套线条字符
+type textGlyphs struct {
+	up, down, wall, mark, red string
+}
+
+var (
+	asciiGlyphs   = textGlyphs{up: "/", down: "\\", wall: "|", mark: "#", red: "*"}
+	unicodeGlyphs = textGlyphs{up: "╱", down: "╲", wall: "│", mark: "┃", red: "•"}
+)
+
+// String 返回带标签的 ASCII 棋盘
+func (b *Board) String() string {
+	return b.Render(TextOptions{Labels: true})
+}
+
+// String 返回局面摘要（阶段、轮次、弃子数）加棋盘
+func (gs *GameState) String() string {
+	head := fmt.Sprintf("Phase%d %s, step %d, discarded %d\n", gs.Phase+1, gs.Turn, gs.PlaceStep, len(gs.Board.Discard))
+	return head + gs.Board.String()
+}
+
+// Render 按 opts 绘制多行文本棋盘
+func (b *Board) Render(opts TextOptions) string {
+	t := textBoard{b: b, opts: opts, g: asciiGlyphs, hl: map[Coordinate]bool{}}
+	if opts.Unicode {
+		t.g = unicodeGlyphs
+	}
+	for _, c := range opts.Highlight {
+		t.hl[c] = true
+	}
+	return t.render()
+}
+
+type textBoard struct {
+	b    *Board
+	opts TextOptions
+	g    textGlyphs
+	hl   map[Coordinate]bool
+}
+
+// -----------------------------------------------------------------------------
+// 辅助：行与缩进
+// -----------------------------------------------------------------------------
+
+// textRows 按行（自上而下）分组返回可落子坐标
+func textRows() [][]Coordinate {
+	out := make([][]Coordinate, BoardHeight)
+	for _, c := range playableCoords {
+		out[c.Y] = append(out[c.Y], c)
+	}
+	return out
+}
+
+// textIndent 返回该行相对最宽行的缩进列数
+func textIndent(row int) int {
+	return abs(row+axialMinR) * textCellWidth / 2
+}
+
+// -----------------------------------------------------------------------------
+// 行片段绘制
+// -----------------------------------------------------------------------------
+
+func (t *textBoard) render() string {
+	rs := textRows()
+	last := len(rs) - 1
+	pad := strings.Repeat(" ", textRowPrefix)
+
+	var lines []string
+	if t.opts.Labels {
+		lines = append(lines, t.columnLabels(rs[0], textIndent(0)))
+	}
+	for y, cells := range rs {
+		ind := textIndent(y)
+		if y == 0 || textIndent(y-1) > ind {
+			// 上半部：本行更宽，上沿直接画出
+			lines = append(lines, pad+spaces(ind)+t.tops(cells))
+		} else {
+			// 下半部：本行更窄，上沿两端补齐上一行的下沿
+			lines = append(lines, pad+spaces(ind-1)+t.g.down+t.tops(cells)+" "+t.g.up)
+		}
+		prefix := pad
+		if t.opts.Labels {
+			prefix = fmt.Sprintf("%*d", -textRowPrefix, y+1)
+		}
+		lines = append(lines, prefix+spaces(ind)+t.middles(cells))
+	}
+	lines = append(lines, pad+spaces(textIndent(last))+strings.Repeat(" "+t.g.down+" "+t.g.up, len(rs[last])))
+	if t.opts.Labels {
+		lines = append(lines, t.columnLabels(rs[last], textIndent(last)))
+	}
+
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// tops 一行格子的上沿：" /*\" 重复
+func (t *textBoard) tops(cells []Coordinate) string {
+	var sb strings.Builder
+	for _, c := range cells {
+		star := " "
+		if containsRed(t.b, c) {
+			star = t.paint(t.g.red, ansiRed)
+		}
+		sb.WriteString(" " + t.g.up + star + t.g.down)
+	}
+	return sb.String()
+}
+
+// middles 一行格子的正文："|W 3|B 1|...|"，高亮格两侧竖边加粗
+func (t *textBoard) middles(cells []Coordinate) string {
+	var sb strings.Builder
+	for i, c := range cells {
+		left := t.g.wall
+		if t.hl[c] || (i > 0 && t.hl[cells[i-1]]) {
+			left = t.g.mark
+		}
+		sb.WriteString(left + t.label(c))
+	}
+	right := t.g.wall
+	if len(cells) > 0 && t.hl[cells[len(cells)-1]] {
+		right = t.g.mark
+	}
+	sb.WriteString(right)
+	return sb.String()
+}
+
+// -----------------------------------------------------------------------------
+// 标签 / 格内文本
+// -----------------------------------------------------------------------------
+
+func (t *textBoard) label(c Coordinate) string {
+	st := innerstack(t.b, c)
+	text := "   "
+	code := ""
+	if len(st) > 0 {
+		text = fmt.Sprintf("%s%2d", st[0].Letter(), len(st))
+		switch st[0] {
+		case White:
+			code = ansiWhite
+		case Black:
+			code = ansiBlack
+		case Red:
+			code = ansiRed
+		}
+	}
+	if t.hl[c] {
+		code += ansiReverse
+	}
+	return t.paint(text, code)
+}
+
+// columnLabels 在一行格子中心处写出其斜列字母
+func (t *textBoard) columnLabels(cells []Coordinate, ind int) string {
+	var sb strings.Builder
+	sb.WriteString(spaces(textRowPrefix + ind))
+	for _, c := range cells {
+		sb.WriteString("  " + FormatCoord(c)[:1] + " ")
+	}
+	return sb.String()
+}
+
+// paint 在开启颜色时用 ANSI 码包裹文本
+func (t *textBoard) paint(s, code string) string {
+	if !t.opts.Color || code == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func spaces(n int) string { return strings.Repeat(" ", max(n, 0)) }
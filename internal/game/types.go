@@ -10,6 +10,25 @@ const (
 	Black
 )
 
+// String 返回颜色名（Red / White / Black）
+func (p Piece) String() string {
+	switch p {
+	case Red:
+		return "Red"
+	case White:
+		return "White"
+	case Black:
+		return "Black"
+	default:
+		return "Unknown"
+	}
+}
+
+// Letter 返回颜色首字母（R / W / B），用于文本棋盘与日志
+func (p Piece) Letter() string {
+	return p.String()[:1]
+}
+
 // Player 用堆顶颜色判定所属方
 type Player uint8
 
@@ -18,6 +37,14 @@ const (
 	PBlack
 )
 
+// String 返回玩家名（White / Black）
+func (p Player) String() string {
+	if p == PBlack {
+		return "Black"
+	}
+	return "White"
+}
+
 // Coordinate 采用整数轴向坐标；可直接作为 map key
 type Coordinate struct {
 	X, Y int
@@ -26,6 +53,15 @@ type Coordinate struct {
 // Stack 为一列自顶向下的棋子
 type Stack []Piece
 
+// String 自顶向下列出棋子首字母，如 "WBR"
+func (s Stack) String() string {
+	out := make([]byte, 0, len(s))
+	for _, p := range s {
+		out = append(out, p.Letter()...)
+	}
+	return string(out)
+}
+
 // Board 持有棋盘上的所有坐标栈及弃子区
 type Board struct {
 	Cells   [BoardWidth][BoardHeight]*Stack // 空格用 nil / len==0 表示
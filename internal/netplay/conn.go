@@ -0,0 +1,548 @@
+// File internal/netplay/conn.go
+package netplay
+
+/*
+局域网双人对局：一方 Host 监听，另一方 Join 连接，TCP 上逐行传输 JSON 消息。
+
+	{"type":"hello","seat":"black","token":"…","moves":["C3",...]}
+	                                                  连接（或重连）后双方各发一次，带上各自完整的着法记录；
+	                                                  seat 与 token 由主机发送，告知对方执子方与座位令牌，
+	                                                  加入方重连时在 hello 中带回 token 以收回座位
+	{"type":"hello","role":"spectator"}                观众的 hello；主机回以完整记录，之后转发双方每一步
+	{"type":"move","ply":12,"move":"C3-E3"}            一步新着法，ply 为它在记录中的序号
+
+双方各自维护一份局面镜像，收到的每步都经 game.Play（内部调用 game.ValidMove）校验后才交给界面。
+断线后主机继续监听、加入方每秒重拨；重连时交换 hello，缺失的着法由记录较长的一方补齐。
+座位一经占用，只有出示令牌的连接才能替换对手，其余连接按观众接入。
+*/
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"dvonn_go/internal/game"
+)
+
+const (
+	protoVersion  = 1
+	dialTimeout   = 5 * time.Second
+	redialBackoff = time.Second
+
+	roleSpectator = "spectator"
+)
+
+var (
+	ErrNotYourTurn = errors.New("netplay: not your turn")
+	ErrDesync      = errors.New("netplay: move history differs from peer")
+	ErrSpectator   = errors.New("netplay: spectators cannot move")
+	ErrSeatTaken   = errors.New("netplay: seat is taken, join as a spectator")
+)
+
+type message struct {
+	Type    string   `json:"type"` // hello / move
+	Version int      `json:"version,omitempty"`
+	Seat    string   `json:"seat,omitempty"`
+	Role    string   `json:"role,omitempty"` // 空为对手，"spectator" 为观众
+	Token   string   `json:"token,omitempty"`
+	Moves   []string `json:"moves,omitempty"`
+	Ply     int      `json:"ply,omitempty"`
+	Move    string   `json:"move,omitempty"`
+}
+
+// Conn 一局网络对局的本地端点。方法可从任意 goroutine 调用。
+type Conn struct {
+	seat      game.Player
+	host      bool
+	spectator bool // 只读观战，双方着法都从 Poll 取出
+	addr      string
+	ln        net.Listener // 仅主机
+
+	mu       sync.Mutex
+	state    game.GameState // 局面镜像
+	history  []string       // 已执行着法（记谱）
+	incoming []game.Move    // 已校验、待界面取走的着法
+	conn     net.Conn
+	out      *outbox // 发往对手的队列
+	status   string
+	closed   bool
+	token    string // 座位令牌：主机在首个对手接入时生成，加入方从主机 hello 中得到
+	synced   bool   // 加入方已接受过主机的初始记录
+
+	watchers map[net.Conn]*outbox // 主机：观众连接
+}
+
+// Host 在 addr 上监听并等待对手；本方执 seat，对局从 gs 开始
+// （gs 可以是已自动摆好的局面，摆子会按记录同步给对方）。
+func Host(addr string, seat game.Player, gs *game.GameState) (*Conn, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &Conn{seat: seat, host: true, addr: ln.Addr().String(), ln: ln, watchers: map[net.Conn]*outbox{}}
+	c.state = *gs
+	c.state.Board = gs.Board.Clone()
+	for _, e := range game.NewRecord(gs, "").Moves {
+		c.history = append(c.history, e.Move)
+	}
+	c.status = "Waiting for opponent on " + c.addr
+	go c.acceptLoop()
+	return c, nil
+}
+
+// Join 连接 addr 上的主机，握手完成（得知执子方）后返回
+func Join(addr string) (*Conn, error) {
+	return dial(&Conn{addr: addr, state: game.StartState()})
+}
+
+// Watch 以观众身份连接 addr 上的主机：先收到完整记录，之后实时收到双方每一步
+func Watch(addr string) (*Conn, error) {
+	return dial(&Conn{addr: addr, state: game.StartState(), spectator: true})
+}
+
+func dial(c *Conn) (*Conn, error) {
+	nc, err := net.DialTimeout("tcp", c.addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(nc)
+	if err := c.handshake(nc, r); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	go c.readLoop(nc, r)
+	return c, nil
+}
+
+// Seat 本方执子方；观众无意义
+func (c *Conn) Seat() game.Player { return c.seat }
+
+// Spectator 是否为观众连接
+func (c *Conn) Spectator() bool { return c.spectator }
+
+// Spectators 主机当前的观众数
+func (c *Conn) Spectators() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.watchers)
+}
+
+// Pending 已收到、尚未被 Poll 取走的着法数；观众据此判断是否在追赶进度
+func (c *Conn) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.incoming)
+}
+
+// Addr 主机为实际监听地址，加入方为所连地址
+func (c *Conn) Addr() string { return c.addr }
+
+// Status 供界面显示的连接状态
+func (c *Conn) Status() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// SeatOpen 主机尚未有对手入座
+func (c *Conn) SeatOpen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.host && c.token == ""
+}
+
+// Connected 当前是否与对手保持连接
+func (c *Conn) Connected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn != nil
+}
+
+// Send 校验并执行本方着法，然后发给对手。断线期间着法保留在记录中，重连后补发。
+func (c *Conn) Send(mv game.Move) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.spectator {
+		return ErrSpectator
+	}
+	if game.ToMove(&c.state) != c.seat {
+		return ErrNotYourTurn
+	}
+	if err := game.Play(&c.state, mv); err != nil {
+		return err
+	}
+	c.history = append(c.history, game.FormatMove(mv))
+	msg := message{Type: "move", Ply: len(c.history), Move: game.FormatMove(mv)}
+	c.writeLocked(msg)
+	c.broadcastLocked(msg)
+	return nil
+}
+
+// Poll 取出一步已校验的对手着法（含重连补齐的着法）；没有则返回 false
+func (c *Conn) Poll() (game.Move, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.incoming) == 0 {
+		return nil, false
+	}
+	mv := c.incoming[0]
+	c.incoming = c.incoming[1:]
+	return mv, true
+}
+
+// Close 断开连接并停止监听 / 重拨；已排队的消息尽量发完后再断开
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.detachLocked()
+	for _, o := range c.watchers {
+		o.close()
+	}
+	c.mu.Unlock()
+	if c.ln != nil {
+		return c.ln.Close()
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// 连接管理
+// -----------------------------------------------------------------------------
+
+// acceptLoop 主机持续接受连接
+func (c *Conn) acceptLoop() {
+	for {
+		nc, err := c.ln.Accept()
+		if err != nil {
+			return // 监听已关闭
+		}
+		go c.admit(nc)
+	}
+}
+
+// admit 读取对方 hello 后按角色接入：首个对手入座，之后只有带正确令牌的连接才能替换对手（用于重连），
+// 其余连接与观众一同加入转发列表
+func (c *Conn) admit(nc net.Conn) {
+	r := bufio.NewReader(nc)
+	nc.SetReadDeadline(time.Now().Add(dialTimeout))
+	msg, err := readMessage(r)
+	nc.SetReadDeadline(time.Time{})
+	if err != nil || msg.Type != "hello" {
+		nc.Close()
+		return
+	}
+	if msg.Role == roleSpectator {
+		c.addWatcher(nc, r)
+		return
+	}
+
+	c.mu.Lock()
+	if c.token != "" && msg.Token != c.token {
+		c.mu.Unlock()
+		c.addWatcher(nc, r)
+		return
+	}
+	if c.token == "" {
+		c.token = newToken()
+	}
+	if c.conn != nil {
+		c.conn.Close()
+		c.detachLocked()
+	}
+	c.attachLocked(nc)
+	c.writeLocked(c.helloLocked())
+	c.mu.Unlock()
+	if err := c.receive(nc, msg); err != nil {
+		nc.Close()
+		c.dropped(nc, err)
+		return
+	}
+	c.readLoop(nc, r)
+}
+
+// addWatcher 接入一名观众：先发完整记录，之后由 broadcastLocked 转发每一步；
+// 观众发来的内容一律忽略，读到 EOF 即移除
+func (c *Conn) addWatcher(nc net.Conn, r *bufio.Reader) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		nc.Close()
+		return
+	}
+	o := newOutbox(nc)
+	o.post(message{Type: "hello", Version: protoVersion, Moves: c.history})
+	c.watchers[nc] = o
+	c.mu.Unlock()
+
+	for {
+		if _, err := readMessage(r); err != nil {
+			break
+		}
+	}
+	nc.Close()
+	o.close()
+	c.mu.Lock()
+	delete(c.watchers, nc)
+	c.mu.Unlock()
+}
+
+// broadcastLocked 把一步转发给全部观众；跟不上的观众直接断开，由其读循环移除
+func (c *Conn) broadcastLocked(msg message) {
+	for nc, o := range c.watchers {
+		if !o.post(msg) {
+			nc.Close()
+		}
+	}
+}
+
+// handshake 加入方：发送 hello 并等待主机的 hello，确定执子方并同步记录
+func (c *Conn) handshake(nc net.Conn, r *bufio.Reader) error {
+	c.mu.Lock()
+	c.attachLocked(nc)
+	c.writeLocked(c.helloLocked())
+	c.mu.Unlock()
+
+	nc.SetReadDeadline(time.Now().Add(dialTimeout))
+	defer nc.SetReadDeadline(time.Time{})
+	msg, err := readMessage(r)
+	if err != nil {
+		return err
+	}
+	if msg.Type != "hello" {
+		return fmt.Errorf("netplay: expected hello, got %q", msg.Type)
+	}
+	return c.receive(nc, msg)
+}
+
+// readLoop 读取消息直到连接断开
+func (c *Conn) readLoop(nc net.Conn, r *bufio.Reader) {
+	var err error
+	for {
+		var msg message
+		if msg, err = readMessage(r); err != nil {
+			break
+		}
+		if err = c.receive(nc, msg); err != nil {
+			break
+		}
+	}
+	nc.Close()
+	c.dropped(nc, err)
+}
+
+// dropped 连接 nc 因 err 断开后更新状态；加入方与观众随后自动重连
+func (c *Conn) dropped(nc net.Conn, err error) {
+	c.mu.Lock()
+	if c.conn != nc {
+		c.mu.Unlock()
+		return // 已被新连接替换或已关闭
+	}
+	c.detachLocked()
+	// 对方发来非法着法或记录不一致时不再重连
+	fatal := errors.Is(err, ErrDesync) || errors.Is(err, game.InvalidMove) || errors.Is(err, game.MoveParseError)
+	switch {
+	case fatal:
+		c.status = "Connection dropped: " + err.Error()
+	case c.host:
+		c.status = "Opponent disconnected, waiting on " + c.addr
+	default:
+		c.status = "Connection lost, reconnecting to " + c.addr
+	}
+	c.mu.Unlock()
+
+	if !c.host && !fatal {
+		c.redial()
+	}
+}
+
+// redial 加入方每隔 redialBackoff 重拨一次，直到成功或 Close
+func (c *Conn) redial() {
+	for {
+		time.Sleep(redialBackoff)
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return
+		}
+		nc, err := net.DialTimeout("tcp", c.addr, dialTimeout)
+		if err != nil {
+			continue
+		}
+		r := bufio.NewReader(nc)
+		if err := c.handshake(nc, r); err != nil {
+			nc.Close()
+			continue
+		}
+		go c.readLoop(nc, r)
+		return
+	}
+}
+
+// attachLocked 把 nc 设为当前连接
+func (c *Conn) attachLocked(nc net.Conn) {
+	c.conn = nc
+	c.out = newOutbox(nc)
+	c.status = c.connectedStatus()
+}
+
+// detachLocked 放弃当前连接；发送队列写完剩余消息后关闭它
+func (c *Conn) detachLocked() {
+	if c.out != nil {
+		c.out.close()
+	}
+	c.conn, c.out = nil, nil
+}
+
+func (c *Conn) connectedStatus() string {
+	if c.spectator {
+		return "Watching " + c.conn.RemoteAddr().String()
+	}
+	return "Connected to " + c.conn.RemoteAddr().String() + " as " + c.seat.String()
+}
+
+func (c *Conn) helloLocked() message {
+	msg := message{Type: "hello", Version: protoVersion, Moves: c.history, Token: c.token}
+	switch {
+	case c.host:
+		msg.Seat = strings.ToLower(opponent(c.seat).String())
+	case c.spectator:
+		msg.Role, msg.Moves, msg.Token = roleSpectator, nil, "" // 观众的记录只会是主机记录的前缀，无需上传
+	}
+	return msg
+}
+
+// writeLocked 把一条消息交给发送队列；队列积满说明对方已不再读取，断开后由读循环处理、重连补齐
+func (c *Conn) writeLocked(msg message) {
+	if c.out != nil && !c.out.post(msg) {
+		c.conn.Close()
+	}
+}
+
+func readMessage(r *bufio.Reader) (message, error) {
+	var msg message
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return msg, err
+	}
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return msg, fmt.Errorf("netplay: bad message: %w", err)
+	}
+	return msg, nil
+}
+
+// -----------------------------------------------------------------------------
+// 着法同步
+// -----------------------------------------------------------------------------
+
+// receive 处理一条来自 nc 的消息
+func (c *Conn) receive(nc net.Conn, msg message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nc {
+		return net.ErrClosed
+	}
+
+	switch msg.Type {
+	case "hello":
+		if msg.Version != protoVersion {
+			return fmt.Errorf("netplay: protocol version %d, want %d", msg.Version, protoVersion)
+		}
+		if !c.host && !c.spectator {
+			if msg.Seat == "" {
+				return ErrSeatTaken // 主机把本连接当作观众接入
+			}
+			seat, err := parseSeat(msg.Seat)
+			if err != nil {
+				return err
+			}
+			c.seat, c.token = seat, msg.Token
+			c.status = c.connectedStatus()
+		}
+		return c.syncLocked(msg.Moves)
+
+	case "move":
+		switch {
+		case msg.Ply <= len(c.history):
+			return nil // 重连时已通过 hello 收到
+		case msg.Ply > len(c.history)+1:
+			return ErrDesync
+		}
+		if !c.spectator && game.ToMove(&c.state) == c.seat {
+			return game.InvalidMove // 对方抢着走了本方的一步
+		}
+		return c.applyLocked(msg.Move)
+	}
+	return fmt.Errorf("netplay: unknown message %q", msg.Type)
+}
+
+// syncLocked 对方记录须与本地记录前缀一致；多出的着法依次校验执行，且只能是对方的着法。
+// 唯一的例外是加入方首次收到的主机记录：开局（含自动摆子）由主机决定，双方的着法都可能出现。
+// 本地更长时对方会在收到我方 hello 后做同样的事。
+func (c *Conn) syncLocked(remote []string) error {
+	n := min(len(remote), len(c.history))
+	for i := 0; i < n; i++ {
+		if remote[i] != c.history[i] {
+			return ErrDesync
+		}
+	}
+	setup := !c.host && !c.synced
+	for _, s := range remote[n:] {
+		if !c.spectator && !setup && game.ToMove(&c.state) == c.seat {
+			return game.InvalidMove // 对方的记录里有本方的着法
+		}
+		if err := c.applyLocked(s); err != nil {
+			return err
+		}
+	}
+	c.synced = true
+	return nil
+}
+
+// applyLocked 解析并校验一步对方着法，成功后放入待取队列
+func (c *Conn) applyLocked(s string) error {
+	mv, err := game.ParseMove(s, &c.state)
+	if err != nil {
+		return err
+	}
+	if err := game.Play(&c.state, mv); err != nil {
+		return err
+	}
+	c.history = append(c.history, s)
+	c.incoming = append(c.incoming, mv)
+	c.broadcastLocked(message{Type: "move", Ply: len(c.history), Move: s})
+	return nil
+}
+
+// newToken 生成一个随机座位令牌
+func newToken() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func parseSeat(s string) (game.Player, error) {
+	switch s {
+	case "white":
+		return game.PWhite, nil
+	case "black":
+		return game.PBlack, nil
+	}
+	return 0, fmt.Errorf("netplay: unknown seat %q", s)
+}
+
+func opponent(p game.Player) game.Player {
+	if p == game.PWhite {
+		return game.PBlack
+	}
+	return game.PWhite
+}
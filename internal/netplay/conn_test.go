@@ -0,0 +1,333 @@
+package netplay
+
+import (
+	"bufio"
+	"dvonn_go/internal/game"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitFor 轮询 cond 直到成立
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// dialHello 以裸 TCP 连接主机并发送 hello
+func dialHello(t *testing.T, addr string, hello message) net.Conn {
+	t.Helper()
+	nc, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewEncoder(nc).Encode(hello); err != nil {
+		t.Fatal(err)
+	}
+	return nc
+}
+
+// rawHello 同 dialHello，并返回主机的回复
+func rawHello(t *testing.T, addr string, hello message) (net.Conn, message) {
+	t.Helper()
+	nc := dialHello(t, addr, hello)
+	nc.SetReadDeadline(time.Now().Add(3 * time.Second))
+	reply, err := readMessage(bufio.NewReader(nc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nc, reply
+}
+
+func TestSeatCannotBeTakenOver(t *testing.T) {
+	gs := game.StartState()
+	host, err := Host("127.0.0.1:0", game.PWhite, &gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+	if !host.SeatOpen() {
+		t.Fatal("seat should be open before anyone joins")
+	}
+
+	guest, err := Join(host.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer guest.Close()
+	if guest.Seat() != game.PBlack || guest.token == "" {
+		t.Fatalf("guest seat %v, token %q", guest.Seat(), guest.token)
+	}
+	waitFor(t, "host to see the guest", host.Connected)
+	if host.SeatOpen() {
+		t.Error("seat still open after the guest joined")
+	}
+
+	// 不带令牌的第三方只能观战，不能顶替对手
+	if _, err := Join(host.Addr()); !errors.Is(err, ErrSeatTaken) {
+		t.Errorf("second join: err %v, want ErrSeatTaken", err)
+	}
+	nc, reply := rawHello(t, host.Addr(), message{Type: "hello", Version: protoVersion, Token: "forged"})
+	defer nc.Close()
+	if reply.Seat != "" || reply.Token != "" {
+		t.Errorf("intruder got seat %q, token %q", reply.Seat, reply.Token)
+	}
+	waitFor(t, "intruder to be admitted as a spectator", func() bool { return host.Spectators() >= 1 })
+	host.mu.Lock()
+	guestConn := host.conn
+	host.mu.Unlock()
+	if guestConn == nil || guestConn.RemoteAddr().String() == nc.LocalAddr().String() {
+		t.Fatal("intruder replaced the opponent connection")
+	}
+
+	// 带令牌的重连收回座位；先关掉 guest，免得它自动重拨与本连接相互顶替
+	token := guest.token
+	guest.Close()
+	re, reply := rawHello(t, host.Addr(), message{Type: "hello", Version: protoVersion, Token: token})
+	defer re.Close()
+	if reply.Seat != "black" {
+		t.Errorf("reconnect with token: seat %q, want black", reply.Seat)
+	}
+	waitFor(t, "reconnect to replace the old connection", func() bool {
+		host.mu.Lock()
+		defer host.mu.Unlock()
+		return host.conn != nil && host.conn.RemoteAddr().String() == re.LocalAddr().String()
+	})
+}
+
+// pair 建立一局本机对局：主机执先手方，返回主机与加入方
+func pair(t *testing.T) (host, guest *Conn) {
+	t.Helper()
+	gs := game.StartState()
+	host, err := Host("127.0.0.1:0", game.ToMove(&gs), &gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { host.Close() })
+	guest, err = Join(host.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { guest.Close() })
+	waitFor(t, "host to see the guest", host.Connected)
+	return host, guest
+}
+
+// send 按 c 的局面镜像解析记谱并走出
+func send(t *testing.T, c *Conn, s string) error {
+	t.Helper()
+	c.mu.Lock()
+	mv, err := game.ParseMove(s, &c.state)
+	c.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.Send(mv)
+}
+
+// poll 等待 c 收到下一步对方着法并返回其记谱
+func poll(t *testing.T, c *Conn) string {
+	t.Helper()
+	var mv game.Move
+	waitFor(t, "a move to arrive", func() bool {
+		var ok bool
+		mv, ok = c.Poll()
+		return ok
+	})
+	return game.FormatMove(mv)
+}
+
+func TestSendPoll(t *testing.T) {
+	host, guest := pair(t)
+
+	if err := send(t, host, "C3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, guest); got != "C3" {
+		t.Fatalf("guest received %q, want C3", got)
+	}
+	if err := send(t, guest, "D3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, host); got != "D3" {
+		t.Fatalf("host received %q, want D3", got)
+	}
+	if host.Pending() != 0 || guest.Pending() != 0 {
+		t.Errorf("own moves were queued: host %d, guest %d", host.Pending(), guest.Pending())
+	}
+}
+
+func TestSendOutOfTurn(t *testing.T) {
+	host, guest := pair(t)
+	if err := send(t, guest, "C3"); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("guest moving first: err %v, want ErrNotYourTurn", err)
+	}
+	if err := send(t, host, "C3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(t, host, "D3"); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("host moving twice: err %v, want ErrNotYourTurn", err)
+	}
+}
+
+// TestRejectRemoteMoves 对方发来的越轮或非法着法都会断开连接，且不会交给界面
+func TestRejectRemoteMoves(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		host  []string // 主机先走的着法
+		hello []string // 入侵者 hello 中的记录
+		move  *message // 之后发来的着法
+	}{
+		{name: "move out of turn", move: &message{Type: "move", Ply: 1, Move: "C3"}},
+		{name: "illegal move", host: []string{"C3"}, move: &message{Type: "move", Ply: 2, Move: "C3"}},
+		{name: "hello out of turn", hello: []string{"C3", "D3"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := game.StartState()
+			host, err := Host("127.0.0.1:0", game.ToMove(&gs), &gs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer host.Close()
+
+			nc := dialHello(t, host.Addr(), message{Type: "hello", Version: protoVersion, Moves: tc.hello})
+			defer nc.Close()
+			waitFor(t, "host to seat the peer", func() bool { return !host.SeatOpen() })
+			for _, s := range tc.host {
+				if err := send(t, host, s); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.move != nil {
+				if err := json.NewEncoder(nc).Encode(tc.move); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			waitFor(t, "host to drop the peer", func() bool { return strings.HasPrefix(host.Status(), "Connection dropped") })
+			if host.Pending() != 0 {
+				t.Errorf("rejected move was queued")
+			}
+			host.mu.Lock()
+			defer host.mu.Unlock()
+			if len(host.history) != len(tc.host) {
+				t.Errorf("history %v, want %v", host.history, tc.host)
+			}
+		})
+	}
+}
+
+// TestRedialResync 断线期间走的着法在加入方重拨成功后补齐
+func TestRedialResync(t *testing.T) {
+	host, guest := pair(t)
+	if err := send(t, host, "C3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, guest); got != "C3" {
+		t.Fatalf("guest received %q", got)
+	}
+
+	host.mu.Lock()
+	nc := host.conn
+	host.mu.Unlock()
+	nc.Close()
+	waitFor(t, "host to notice the drop", func() bool { return !host.Connected() })
+
+	if err := send(t, guest, "D3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, host); got != "D3" {
+		t.Fatalf("host received %q after resync, want D3", got)
+	}
+	if err := send(t, host, "E3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, guest); got != "E3" {
+		t.Fatalf("guest received %q after resync, want E3", got)
+	}
+}
+
+// TestWatchMidGame 中途加入的观众先补齐已有着法，再实时收到后续着法，且不能走子
+func TestWatchMidGame(t *testing.T) {
+	host, guest := pair(t)
+	if err := send(t, host, "C3"); err != nil {
+		t.Fatal(err)
+	}
+	poll(t, guest)
+	if err := send(t, guest, "D3"); err != nil {
+		t.Fatal(err)
+	}
+	poll(t, host)
+
+	w, err := Watch(host.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if !w.Spectator() {
+		t.Fatal("Watch did not return a spectator")
+	}
+	waitFor(t, "host to register the watcher", func() bool { return host.Spectators() == 1 })
+	if got := []string{poll(t, w), poll(t, w)}; got[0] != "C3" || got[1] != "D3" {
+		t.Fatalf("watcher caught up with %v", got)
+	}
+
+	if err := send(t, host, "E3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, w); got != "E3" {
+		t.Fatalf("watcher received %q, want E3", got)
+	}
+	if err := send(t, w, "F3"); !errors.Is(err, ErrSpectator) {
+		t.Errorf("watcher move: err %v, want ErrSpectator", err)
+	}
+}
+
+// TestStalledWatcher 不读取的观众不能卡住走子，积满队列后被断开
+func TestStalledWatcher(t *testing.T) {
+	host, guest := pair(t)
+	stalled, peer := net.Pipe() // net.Pipe 无缓冲：对端不读，写就一直阻塞
+	defer peer.Close()
+	host.mu.Lock()
+	host.watchers[stalled] = newOutbox(stalled)
+	host.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		host.mu.Lock()
+		defer host.mu.Unlock()
+		for i := 0; i <= outboxSize+1; i++ {
+			host.broadcastLocked(message{Type: "move", Ply: i + 1, Move: "C3"})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast blocked on a stalled watcher")
+	}
+	// 排空被阻塞的那条消息后应读到连接已关闭
+	waitFor(t, "stalled watcher to be closed", func() bool {
+		peer.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		_, err := peer.Read(make([]byte, 4096))
+		return err != nil && !errors.Is(err, os.ErrDeadlineExceeded)
+	})
+
+	// 对局本身不受影响
+	if err := send(t, host, "C3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := poll(t, guest); got != "C3" {
+		t.Fatalf("guest received %q", got)
+	}
+}
@@ -0,0 +1,207 @@
+// File internal/netplay/discovery.go
+package netplay
+
+/*
+局域网发现：建局方每隔 AnnounceInterval 向 UDP 广播地址发送一条 JSON 公告，
+大厅用 Browser 监听同一端口，收集仍在公告的对局。
+
+	{"game":"dvonn","version":1,"kind":"host","name":"alice-pc","addr":":7777","seat":"black"}
+
+addr 不含主机名时，Browser 以报文来源 IP 补全。
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DiscoveryPort    = 7778
+	AnnounceInterval = time.Second
+	announceTTL      = 3 * AnnounceInterval // 超过此时长未再收到公告即视为已关闭
+	gameTag          = "dvonn"
+)
+
+// 公告类型
+const (
+	KindHost   = "host"   // GUI 建局，等待 join
+	KindServer = "server" // dvonn-server，HTTP 接口
+)
+
+// Announcement 一条对局公告
+type Announcement struct {
+	Game     string `json:"game"`
+	Version  int    `json:"version"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Addr     string `json:"addr"`               // host 为 TCP 地址，server 为 HTTP 地址
+	Seat     string `json:"seat,omitempty"`     // host：留给加入方的执子方；空表示对局已开始，只能观战
+	Watchers int    `json:"watchers,omitempty"` // host：观众数
+	Games    int    `json:"games,omitempty"`    // server：托管中的对局数
+}
+
+// Announcer 周期性广播公告
+type Announcer struct {
+	conn *net.UDPConn
+	next func() (Announcement, bool)
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Announce 每隔 AnnounceInterval 调用 next 并把结果发到 target（如 "255.255.255.255:7778"）；
+// next 返回 false 时本轮不发送（例如对手已连上，对局不再开放）。
+func Announce(target string, next func() (Announcement, bool)) (*Announcer, error) {
+	raddr, err := net.ResolveUDPAddr("udp4", target)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialUDP("udp4", nil, raddr)
+	if err != nil {
+		return nil, err
+	}
+	a := &Announcer{conn: conn, next: next, stop: make(chan struct{}), done: make(chan struct{})}
+	go a.loop()
+	return a, nil
+}
+
+// BroadcastTarget 返回本机广播地址上的发现端口
+func BroadcastTarget() string {
+	return net.JoinHostPort(net.IPv4bcast.String(), strconv.Itoa(DiscoveryPort))
+}
+
+func (a *Announcer) loop() {
+	defer close(a.done)
+	t := time.NewTicker(AnnounceInterval)
+	defer t.Stop()
+	for {
+		if ann, ok := a.next(); ok {
+			ann.Game, ann.Version = gameTag, protoVersion
+			if data, err := json.Marshal(ann); err == nil {
+				_, _ = a.conn.Write(data)
+			}
+		}
+		select {
+		case <-t.C:
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// Close 停止广播
+func (a *Announcer) Close() error {
+	close(a.stop)
+	<-a.done
+	return a.conn.Close()
+}
+
+// Browser 监听公告并维护当前可见的对局列表
+type Browser struct {
+	conn *net.UDPConn
+
+	mu    sync.Mutex
+	games map[string]seen // 以补全后的 Addr 为键
+}
+
+type seen struct {
+	ann Announcement
+	at  time.Time
+}
+
+// Browse 在 addr（如 ":7778"）上监听公告
+func Browse(addr string) (*Browser, error) {
+	laddr, err := net.ResolveUDPAddr("udp4", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp4", laddr)
+	if err != nil {
+		return nil, err
+	}
+	b := &Browser{conn: conn, games: map[string]seen{}}
+	go b.loop()
+	return b, nil
+}
+
+func (b *Browser) loop() {
+	buf := make([]byte, 2048)
+	for {
+		n, from, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			return // 已关闭
+		}
+		var ann Announcement
+		if json.Unmarshal(buf[:n], &ann) != nil || ann.Game != gameTag || ann.Version != protoVersion {
+			continue
+		}
+		ann.Addr = completeAddr(ann.Addr, from.IP)
+		b.mu.Lock()
+		b.games[ann.Addr] = seen{ann: ann, at: time.Now()}
+		b.mu.Unlock()
+	}
+}
+
+// Games 返回仍在公告的对局，按名称排序
+func (b *Browser) Games() []Announcement {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []Announcement
+	for key, s := range b.games {
+		if time.Since(s.at) > announceTTL {
+			delete(b.games, key)
+			continue
+		}
+		out = append(out, s.ann)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Addr < out[j].Addr
+	})
+	return out
+}
+
+// Close 停止监听
+func (b *Browser) Close() error { return b.conn.Close() }
+
+// completeAddr 为缺少主机名的地址（":7777"、"http://:8080"）补上来源 IP
+func completeAddr(addr string, ip net.IP) string {
+	scheme := ""
+	if rest, ok := strings.CutPrefix(addr, "http://"); ok {
+		scheme, addr = "http://", rest
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return scheme + addr
+	}
+	if h := net.ParseIP(host); host == "" || (h != nil && h.IsUnspecified()) {
+		host = ip.String()
+	}
+	return scheme + net.JoinHostPort(host, port)
+}
+
+// Announce 主机持续公告本局；对手入座后不再留座（断线期间也为其保留），仍可观战。name 为大厅中显示的名称
+func (c *Conn) Announce(target, name string) (*Announcer, error) {
+	if !c.host {
+		return nil, errors.New("netplay: only the host announces")
+	}
+	return Announce(target, func() (Announcement, bool) {
+		ann := Announcement{
+			Kind:     KindHost,
+			Name:     name,
+			Addr:     c.addr,
+			Watchers: c.Spectators(),
+		}
+		if c.SeatOpen() {
+			ann.Seat = strings.ToLower(opponent(c.seat).String())
+		}
+		return ann, true
+	})
+}
@@ -0,0 +1,100 @@
+package netplay
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestCompleteAddr(t *testing.T) {
+	ip := net.IPv4(192, 168, 1, 20)
+	for _, tc := range []struct{ in, want string }{
+		{":7777", "192.168.1.20:7777"},
+		{"0.0.0.0:7777", "192.168.1.20:7777"},
+		{"10.0.0.5:7777", "10.0.0.5:7777"},
+		{"http://:8080", "http://192.168.1.20:8080"},
+		{"http://[::]:8080", "http://192.168.1.20:8080"},
+		{"http://example.org:8080", "http://example.org:8080"},
+		{"not-an-addr", "not-an-addr"},
+	} {
+		if got := completeAddr(tc.in, ip); got != tc.want {
+			t.Errorf("completeAddr(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+// waitGames 轮询 Browser 直到收到 n 条公告
+func waitGames(t *testing.T, b *Browser, n int) []Announcement {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		games := b.Games()
+		if len(games) >= n {
+			return games
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d announcements, want %d", len(games), n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAnnounceBrowseLoopback(t *testing.T) {
+	b, err := Browse("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	target := b.conn.LocalAddr().String()
+
+	host, err := Announce(target, func() (Announcement, bool) {
+		return Announcement{Kind: KindHost, Name: "alice", Addr: ":7777", Seat: "white"}, true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+	server, err := Announce(target, func() (Announcement, bool) {
+		return Announcement{Kind: KindServer, Name: "bob", Addr: "http://:8080", Games: 2}, true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	closed, err := Announce(target, func() (Announcement, bool) {
+		return Announcement{Kind: KindHost, Name: "carol", Addr: ":7779"}, false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closed.Close()
+
+	games := waitGames(t, b, 2)
+	if len(games) != 2 {
+		t.Fatalf("got %d announcements, want 2: %+v", len(games), games)
+	}
+	alice, bob := games[0], games[1]
+	if alice.Name != "alice" || alice.Kind != KindHost || alice.Addr != "127.0.0.1:7777" || alice.Seat != "white" {
+		t.Errorf("host announcement = %+v", alice)
+	}
+	if alice.Game != gameTag || alice.Version != protoVersion {
+		t.Errorf("announcement not tagged: %+v", alice)
+	}
+	if bob.Name != "bob" || bob.Kind != KindServer || bob.Addr != "http://127.0.0.1:8080" || bob.Games != 2 {
+		t.Errorf("server announcement = %+v", bob)
+	}
+}
+
+func TestBrowserExpiresStaleGames(t *testing.T) {
+	b := &Browser{games: map[string]seen{
+		"fresh:1": {ann: Announcement{Name: "fresh", Addr: "fresh:1"}, at: time.Now()},
+		"stale:1": {ann: Announcement{Name: "stale", Addr: "stale:1"}, at: time.Now().Add(-announceTTL - time.Millisecond)},
+	}}
+	games := b.Games()
+	if len(games) != 1 || games[0].Name != "fresh" {
+		t.Fatalf("Games() = %+v, want only the fresh entry", games)
+	}
+	if _, ok := b.games["stale:1"]; ok {
+		t.Errorf("stale entry was not pruned")
+	}
+}
@@ -0,0 +1,78 @@
+// File internal/netplay/outbox.go
+package netplay
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	outboxSize   = 64              // 每个连接最多积压的消息数
+	writeTimeout = 5 * time.Second // 单条消息的写超时
+)
+
+// outbox 一个连接的发送队列：由独立的 goroutine 带写超时逐条写出，
+// 调用方（持有 Conn.mu）只做非阻塞入队，不会被迟迟不读的对端卡住
+type outbox struct {
+	nc   net.Conn
+	msgs chan message
+	stop chan struct{}
+	once sync.Once
+}
+
+func newOutbox(nc net.Conn) *outbox {
+	o := &outbox{nc: nc, msgs: make(chan message, outboxSize), stop: make(chan struct{})}
+	go o.loop()
+	return o
+}
+
+// post 入队一条消息；队列已满（对端跟不上）或已关闭时返回 false
+func (o *outbox) post(msg message) bool {
+	select {
+	case <-o.stop:
+		return false
+	default:
+	}
+	select {
+	case o.msgs <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close 停止发送：已入队的消息尽量写完后关闭连接
+func (o *outbox) close() {
+	o.once.Do(func() { close(o.stop) })
+}
+
+func (o *outbox) loop() {
+	enc := json.NewEncoder(o.nc)
+	write := func(msg message) bool {
+		o.nc.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return enc.Encode(msg) == nil
+	}
+	for {
+		select {
+		case msg := <-o.msgs:
+			if !write(msg) {
+				o.nc.Close() // 读循环随之退出并清理
+				return
+			}
+		case <-o.stop:
+			for {
+				select {
+				case msg := <-o.msgs:
+					if write(msg) {
+						continue
+					}
+				default:
+				}
+				o.nc.Close()
+				return
+			}
+		}
+	}
+}
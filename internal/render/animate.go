@@ -0,0 +1,235 @@
+// File internal/render/animate.go
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"dvonn_go/internal/game"
+)
+
+// AnimOptions 整局动画参数；零值字段取默认值
+type AnimOptions struct {
+	Options                  // 尺寸、坐标标签（Highlight / Arrows 不用于动画）
+	Delay      time.Duration // 每步跳子后停留时长，默认 800ms；开局与终局画面停留更久
+	PlaceDelay time.Duration // 每步摆子后停留时长，默认 150ms
+	FrameDelay time.Duration // 跳子与淡出过渡帧的间隔，默认 40ms
+	JumpFrames int           // 跳子过渡帧数，默认 10（同 GUI 的 animFrames）
+	FadeFrames int           // 弃子淡出帧数，默认 15（同回放的 fadeFrames）
+}
+
+func (o AnimOptions) withDefaults() AnimOptions {
+	if o.Delay <= 0 {
+		o.Delay = 800 * time.Millisecond
+	}
+	if o.PlaceDelay <= 0 {
+		o.PlaceDelay = 150 * time.Millisecond
+	}
+	if o.FrameDelay <= 0 {
+		o.FrameDelay = 40 * time.Millisecond
+	}
+	if o.JumpFrames <= 0 {
+		o.JumpFrames = 10
+	}
+	if o.FadeFrames <= 0 {
+		o.FadeFrames = 15
+	}
+	o.Highlight, o.Arrows = nil, nil
+	return o
+}
+
+// Frames 重放记录并逐帧回调 emit：摆子一帧，跳子为飞行过渡帧，
+// 之后被移除的连通块逐帧淡出，最后停在新局面上。
+func Frames(rec *game.Record, opts AnimOptions, emit func(img *image.RGBA, delay time.Duration) error) error {
+	opts = opts.withDefaults()
+	states, moves, err := rec.Replay()
+	if err != nil {
+		return err
+	}
+	g := newGeometry(opts.Size)
+	frame := func(b *game.Board, sc scene, delay time.Duration) error {
+		cv := newRaster(g)
+		drawScene(cv, g, b, opts.Options, sc)
+		return emit(cv.dst, delay)
+	}
+
+	if err := frame(&states[0].Board, scene{}, 2*opts.Delay); err != nil {
+		return err
+	}
+	for i, mv := range moves {
+		before, after := &states[i], &states[i+1]
+		hold := opts.Delay
+		if i == len(moves)-1 {
+			hold = 4 * opts.Delay
+		}
+
+		jm, ok := mv.(game.JumpMove)
+		if !ok {
+			if i < len(moves)-1 {
+				hold = opts.PlaceDelay
+			}
+			if err := frame(&after.Board, scene{}, hold); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// 跳子：起点隐藏，堆顶一枚沿直线飞到终点
+		for f := 0; f < opts.JumpFrames; f++ {
+			sc := scene{
+				hide: &jm.From,
+				fly:  &flying{piece: jm.Player.Piece(), from: jm.From, to: jm.To, t: float64(f) / float64(opts.JumpFrames)},
+			}
+			if err := frame(&before.Board, sc, opts.FrameDelay); err != nil {
+				return err
+			}
+		}
+		// 合并后、清理前的棋盘上，断开的连通块逐帧淡出
+		merged, gone := discarded(before, jm, after)
+		for f := 0; len(gone) > 0 && f < opts.FadeFrames; f++ {
+			alpha := 1 - float64(f)/float64(opts.FadeFrames)
+			fade := make(map[game.Coordinate]float64, len(gone))
+			for _, c := range gone {
+				fade[c] = alpha
+			}
+			if err := frame(&merged, scene{fade: fade}, opts.FrameDelay); err != nil {
+				return err
+			}
+		}
+		if err := frame(&after.Board, scene{}, hold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discarded 返回跳子合并后、清理前的棋盘，以及其中在 after 里已被移除的格子
+func discarded(before *game.GameState, mv game.JumpMove, after *game.GameState) (game.Board, []game.Coordinate) {
+	b := before.Board.Clone()
+	from, to := b.Cells[mv.From.X][mv.From.Y], b.Cells[mv.To.X][mv.To.Y]
+	if from != nil && to != nil {
+		merged := append(append(game.Stack(nil), *from...), *to...)
+		b.Cells[mv.To.X][mv.To.Y] = &merged
+		b.Cells[mv.From.X][mv.From.Y] = nil
+	}
+	var gone []game.Coordinate
+	game.ForEachPlayable(func(c game.Coordinate) {
+		st, now := b.Cells[c.X][c.Y], after.Board.Cells[c.X][c.Y]
+		if st != nil && len(*st) > 0 && (now == nil || len(*now) == 0) {
+			gone = append(gone, c)
+		}
+	})
+	return b, gone
+}
+
+// GIF 把整局导出为循环播放的 GIF 动画。除首帧外每帧只编码与上一帧不同的矩形区域。
+func GIF(w io.Writer, rec *game.Record, opts AnimOptions) error {
+	anim := &gif.GIF{}
+	q := newQuantizer(palette.Plan9)
+	var prev *image.Paletted
+	err := Frames(rec, opts, func(img *image.RGBA, delay time.Duration) error {
+		cur := q.paletted(img)
+		frame := cur
+		if prev != nil {
+			frame = cur.SubImage(changed(prev, cur)).(*image.Paletted)
+		} else {
+			anim.Config = image.Config{ColorModel: cur.Palette, Width: cur.Rect.Dx(), Height: cur.Rect.Dy()}
+		}
+		prev = cur
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// changed 返回两帧间有差异像素的外包矩形；完全相同时返回左上角 1×1
+func changed(a, b *image.Paletted) image.Rectangle {
+	bounds := b.Bounds()
+	minX, minY, maxX, maxY := bounds.Max.X, bounds.Max.Y, bounds.Min.X-1, bounds.Min.Y-1
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if a.ColorIndexAt(x, y) != b.ColorIndexAt(x, y) {
+				minX, maxX = min(minX, x), max(maxX, x)
+				minY, maxY = min(minY, y), max(maxY, y)
+			}
+		}
+	}
+	if maxX < minX {
+		return image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+1, bounds.Min.Y+1)
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1)
+}
+
+// PNGFrames 把整局导出为 dir 下的 frame-0001.png… 序列，并写出 ffmpeg concat 格式的
+// frames.txt 记录每帧时长（ffmpeg -f concat -i frames.txt out.mp4）
+func PNGFrames(dir string, rec *game.Record, opts AnimOptions) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	list, err := os.Create(filepath.Join(dir, "frames.txt"))
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	err = Frames(rec, opts, func(img *image.RGBA, delay time.Duration) error {
+		n++
+		name := fmt.Sprintf("frame-%04d.png", n)
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(f, img); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(list, "file '%s'\nduration %.3f\n", name, delay.Seconds())
+		return err
+	})
+	if cerr := list.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// quantizer 把 RGBA 帧映射到固定调色板；同色像素在各帧间大量重复，缓存查找结果
+type quantizer struct {
+	pal   color.Palette
+	cache map[color.RGBA]uint8
+}
+
+func newQuantizer(pal color.Palette) *quantizer {
+	return &quantizer{pal: pal, cache: map[color.RGBA]uint8{}}
+}
+
+func (q *quantizer) paletted(img *image.RGBA) *image.Paletted {
+	b := img.Bounds()
+	out := image.NewPaletted(b, q.pal)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			idx, ok := q.cache[c]
+			if !ok {
+				idx = uint8(q.pal.Index(c))
+				q.cache[c] = idx
+			}
+			out.SetColorIndex(x, y, idx)
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+// File internal/render/raster.go
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+
+	"dvonn_go/internal/assets"
+	"dvonn_go/internal/game"
+)
+
+// Image 把局面画成位图
+func Image(gs *game.GameState, opts Options) *image.RGBA {
+	g := newGeometry(opts.Size)
+	cv := newRaster(g)
+	drawPosition(cv, g, gs, opts)
+	return cv.dst
+}
+
+// PNG 把局面以 PNG 写入 w
+func PNG(w io.Writer, gs *game.GameState, opts Options) error {
+	return png.Encode(w, Image(gs, opts))
+}
+
+// raster 用 x/image/vector 在 RGBA 位图上绘制
+type raster struct {
+	dst    *image.RGBA
+	pieces map[game.Piece]image.Image // 按当前尺寸缩放好的贴图
+	size   int
+}
+
+func newRaster(g geometry) *raster {
+	dst := image.NewRGBA(image.Rect(0, 0, g.w, g.h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+	return &raster{dst: dst, pieces: map[game.Piece]image.Image{}, size: int(math.Round(g.r))}
+}
+
+func (rs *raster) line(x1, y1, x2, y2, width float64, col color.Color) {
+	dx, dy := x2-x1, y2-y1
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	nx, ny := -dy/l*width/2, dx/l*width/2
+	rs.polygon([]point{{x1 + nx, y1 + ny}, {x2 + nx, y2 + ny}, {x2 - nx, y2 - ny}, {x1 - nx, y1 - ny}}, col)
+}
+
+func (rs *raster) disc(x, y, r float64, col color.Color) {
+	const segments = 48
+	pts := make([]point, segments)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / segments
+		pts[i] = point{x + r*math.Cos(a), y + r*math.Sin(a)}
+	}
+	rs.polygon(pts, col)
+}
+
+// polygon 只在多边形外包矩形内光栅化，避免每个图形都扫描整幅图
+func (rs *raster) polygon(pts []point, col color.Color) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	box := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(rs.dst.Bounds())
+	if box.Empty() {
+		return
+	}
+	ox, oy := float32(box.Min.X), float32(box.Min.Y)
+	z := vector.NewRasterizer(box.Dx(), box.Dy())
+	z.MoveTo(float32(pts[0].x)-ox, float32(pts[0].y)-oy)
+	for _, p := range pts[1:] {
+		z.LineTo(float32(p.x)-ox, float32(p.y)-oy)
+	}
+	z.ClosePath()
+	z.Draw(rs.dst, box, image.NewUniform(col), image.Point{})
+}
+
+func (rs *raster) piece(p game.Piece, x, y, _, alpha float64) {
+	img, ok := rs.pieces[p]
+	if !ok {
+		scaled := image.NewRGBA(image.Rect(0, 0, rs.size, rs.size))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), assets.Piece(p), assets.Piece(p).Bounds(), draw.Src, nil)
+		rs.pieces[p], img = scaled, scaled
+	}
+	at := image.Pt(int(math.Round(x)), int(math.Round(y)))
+	r := image.Rectangle{Min: at, Max: at.Add(img.Bounds().Size())}
+	if alpha >= 1 {
+		draw.Draw(rs.dst, r, img, image.Point{}, draw.Over)
+		return
+	}
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(math.Max(alpha, 0) * 0xFF))})
+	draw.DrawMask(rs.dst, r, img, image.Point{}, mask, image.Point{}, draw.Over)
+}
+
+func (rs *raster) text(s string, x, y float64, col color.Color) {
+	d := font.Drawer{
+		Dst:  rs.dst,
+		Src:  image.NewUniform(col),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(int(math.Round(x)), int(math.Round(y))),
+	}
+	d.DrawString(s)
+}
@@ -0,0 +1,244 @@
+// File internal/render/render.go
+package render
+
+/*
+无窗口棋盘渲染：把局面画成 image.Image / PNG 或 SVG，用于文档、bug 报告与题目。
+画面与 GUI 一致（六边形网格、棋子贴图、堆高数字），尺寸按 Options.Size 缩放。
+位图与 SVG 共用同一套绘制流程，差别只在 canvas 实现。
+*/
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"dvonn_go/internal/game"
+)
+
+// DefaultSize 为默认图像宽度，与 GUI 画布同宽
+const DefaultSize = 1300
+
+// 与 GUI 相同的配色
+var (
+	backgroundColor = color.RGBA{0xDD, 0xDD, 0xDD, 0xFF}
+	lineColor       = color.RGBA{0x44, 0x44, 0x44, 0xFF}
+	circleColor     = color.RGBA{0xB0, 0xC4, 0xDE, 0xFF}
+	highlightColor  = color.RGBA{0x00, 0x66, 0xFF, 0xFF}
+	arrowColor      = color.RGBA{0x00, 0xCC, 0x66, 0xE0}
+	labelColor      = color.RGBA{0x44, 0x44, 0x44, 0xFF}
+)
+
+// Arrow 从 From 指向 To 的箭头；Color 为 nil 时用默认绿色
+type Arrow struct {
+	From, To game.Coordinate
+	Color    color.Color
+}
+
+// Options 渲染参数
+type Options struct {
+	Size      int               // 图像宽度（像素），高度按比例；0 为 DefaultSize
+	Labels    bool              // 在格子下方标注标准坐标（如 "C3"）
+	Highlight []game.Coordinate // 高亮的格子
+	Arrows    []Arrow
+}
+
+// Bounds 返回 opts 下图像的宽高
+func Bounds(opts Options) (w, h int) {
+	g := newGeometry(opts.Size)
+	return g.w, g.h
+}
+
+// -----------------------------------------------------------------------------
+// 几何
+// -----------------------------------------------------------------------------
+
+// 以格半径 R 为单位的边距：上方留出高堆与堆高数字的空间
+const (
+	marginSide   = 0.8
+	marginTop    = 1.3
+	marginBottom = 0.9
+	layerOffset  = 6.0 / 70 // 每层棋子的垂直偏移，GUI 中 R=70 时为 6 像素
+)
+
+type geometry struct {
+	r      float64 // 相当于 GUI 的 triangleR
+	ox, oy float64 // 轴向坐标 (0,0) 的像素位置
+	w, h   int
+}
+
+func newGeometry(size int) geometry {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	// R=1 时棋盘中心的外包范围
+	minX, maxX, minY, maxY := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	game.ForEachPlayable(func(c game.Coordinate) {
+		x, y := axialPixel(c, 1)
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	})
+	r := float64(size) / (maxX - minX + 2*marginSide)
+	return geometry{
+		r:  r,
+		ox: (marginSide - minX) * r,
+		oy: (marginTop - minY) * r,
+		w:  size,
+		h:  int(math.Ceil((maxY - minY + marginTop + marginBottom) * r)),
+	}
+}
+
+// axialPixel 与 GUI 的 coordToScreen 相同的换算，不含偏移
+func axialPixel(c game.Coordinate, r float64) (float64, float64) {
+	q, rr := game.AxialFromIndex(c)
+	return r * (math.Sqrt(3)*float64(q) + math.Sqrt(3)/2*float64(rr)), r * 1.5 * float64(rr)
+}
+
+// center 返回格子中心的像素坐标
+func (g geometry) center(c game.Coordinate) (float64, float64) {
+	x, y := axialPixel(c, g.r)
+	return g.ox + x, g.oy + y
+}
+
+// -----------------------------------------------------------------------------
+// 绘制流程
+// -----------------------------------------------------------------------------
+
+type point struct{ x, y float64 }
+
+// canvas 由位图与 SVG 分别实现
+type canvas interface {
+	line(x1, y1, x2, y2, width float64, col color.Color)
+	disc(x, y, r float64, col color.Color)
+	polygon(pts []point, col color.Color)
+	// piece 把棋子贴图画在以 (x,y) 为左上角、边长 size 的方块内
+	piece(p game.Piece, x, y, size, alpha float64)
+	// text 以 (x,y) 为基线起点写一行 7x13 等宽字
+	text(s string, x, y float64, col color.Color)
+}
+
+// neighbourDirs 只取三个正方向，每条网格线恰好画一次
+var neighbourDirs = []game.Coordinate{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 1}}
+
+// drawBoard 画网格、格子、高亮与坐标
+func drawBoard(cv canvas, g geometry, opts Options) {
+	lw := math.Max(1, g.r/50)
+	game.ForEachPlayable(func(c game.Coordinate) {
+		x1, y1 := g.center(c)
+		for _, d := range neighbourDirs {
+			n := c.Add(d)
+			if !game.IsPlayable(n) {
+				continue
+			}
+			x2, y2 := g.center(n)
+			cv.line(x1, y1, x2, y2, lw, lineColor)
+		}
+	})
+
+	hl := map[game.Coordinate]bool{}
+	for _, c := range opts.Highlight {
+		hl[c] = true
+	}
+	game.ForEachPlayable(func(c game.Coordinate) {
+		x, y := g.center(c)
+		col := circleColor
+		if hl[c] {
+			col = highlightColor
+		}
+		cv.disc(x, y, g.r/3, col)
+		if opts.Labels {
+			cv.text(game.FormatCoord(c), x-7, y+g.r/2+12, labelColor)
+		}
+	})
+}
+
+// drawStack 自底向上画一堆棋子并在堆顶右侧标注高度，与 GUI 的 drawStack 一致
+func drawStack(cv canvas, g geometry, st game.Stack, x, y, alpha float64) {
+	if len(st) == 0 {
+		return
+	}
+	size := g.r
+	layer := g.r * layerOffset
+	for i := len(st) - 1; i >= 0; i-- {
+		py := y - float64(len(st)-1-i)*layer
+		cv.piece(st[i], x-size/2, py-size/2, size, alpha)
+	}
+	if alpha < 1 {
+		return
+	}
+	label := strconv.Itoa(len(st))
+	lx := x + size/2 - 4
+	ly := y - float64(len(st)-1)*layer + 10
+	cv.text(label, lx, ly, color.Black)
+	cv.text(label, lx-1, ly-1, color.White)
+}
+
+// drawArrow 画一支从格子中心指向另一格、末端略缩进的箭头
+func drawArrow(cv canvas, g geometry, a Arrow) {
+	col := a.Color
+	if col == nil {
+		col = arrowColor
+	}
+	fx, fy := g.center(a.From)
+	tx, ty := g.center(a.To)
+	dx, dy := tx-fx, ty-fy
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	ux, uy := dx/length, dy/length
+	width := g.r / 12
+	head := g.r / 3
+	ex, ey := tx-ux*g.r/4, ty-uy*g.r/4 // 箭尖
+	bx, by := ex-ux*head, ey-uy*head   // 箭头底边中点
+	cv.line(fx, fy, bx, by, width, col)
+	cv.polygon([]point{
+		{ex, ey},
+		{bx - uy*head/2, by + ux*head/2},
+		{bx + uy*head/2, by - ux*head/2},
+	}, col)
+}
+
+// drawPosition 画完整局面
+func drawPosition(cv canvas, g geometry, gs *game.GameState, opts Options) {
+	drawScene(cv, g, &gs.Board, opts, scene{})
+}
+
+// scene 动画帧在静态局面之上的附加内容
+type scene struct {
+	hide *game.Coordinate // 不画该格（正在飞行的堆的起点）
+	fly  *flying
+	fade map[game.Coordinate]float64 // 按不透明度淡出的格子
+}
+
+// flying 沿跳子路径飞行中的棋子，与 GUI 的 Animation 一样只画堆顶一枚
+type flying struct {
+	piece    game.Piece
+	from, to game.Coordinate
+	t        float64 // 0–1
+}
+
+func drawScene(cv canvas, g geometry, b *game.Board, opts Options, sc scene) {
+	drawBoard(cv, g, opts)
+	game.ForEachPlayable(func(c game.Coordinate) {
+		if sc.hide != nil && c == *sc.hide {
+			return
+		}
+		if st := b.Cells[c.X][c.Y]; st != nil {
+			alpha, fading := sc.fade[c]
+			if !fading {
+				alpha = 1
+			}
+			x, y := g.center(c)
+			drawStack(cv, g, *st, x, y, alpha)
+		}
+	})
+	if f := sc.fly; f != nil {
+		fx, fy := g.center(f.from)
+		tx, ty := g.center(f.to)
+		x, y := fx+(tx-fx)*f.t, fy+(ty-fy)*f.t
+		cv.piece(f.piece, x-g.r/2, y-g.r/2, g.r, 1)
+	}
+	for _, a := range opts.Arrows {
+		drawArrow(cv, g, a)
+	}
+}
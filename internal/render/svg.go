@@ -0,0 +1,98 @@
+// File internal/render/svg.go
+package render
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"dvonn_go/internal/assets"
+	"dvonn_go/internal/game"
+)
+
+// SVG 把局面以 SVG 写入 w；棋子贴图以 PNG 内嵌，只包含用到的颜色
+func SVG(w io.Writer, gs *game.GameState, opts Options) error {
+	g := newGeometry(opts.Size)
+	cv := &svgCanvas{}
+	drawPosition(cv, g, gs, opts)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", g.w, g.h, g.w, g.h)
+	if len(cv.used) > 0 {
+		bw.WriteString("<defs>\n")
+		for _, p := range []game.Piece{game.Red, game.White, game.Black} {
+			if !cv.used[p] {
+				continue
+			}
+			b := assets.Piece(p).Bounds()
+			fmt.Fprintf(bw, `<symbol id="%s" viewBox="0 0 %d %d"><image width="%d" height="%d" href="data:image/png;base64,%s"/></symbol>`+"\n",
+				pieceID(p), b.Dx(), b.Dy(), b.Dx(), b.Dy(), base64.StdEncoding.EncodeToString(assets.PiecePNG(p)))
+		}
+		bw.WriteString("</defs>\n")
+	}
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", g.w, g.h, svgColor(backgroundColor))
+	bw.WriteString(cv.body.String())
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// svgCanvas 把图元累积为 SVG 元素
+type svgCanvas struct {
+	body strings.Builder
+	used map[game.Piece]bool
+}
+
+func (s *svgCanvas) line(x1, y1, x2, y2, width float64, col color.Color) {
+	fmt.Fprintf(&s.body, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"%s stroke-width="%.1f"/>`+"\n",
+		x1, y1, x2, y2, svgColor(col), svgOpacity("stroke-opacity", col), width)
+}
+
+func (s *svgCanvas) disc(x, y, r float64, col color.Color) {
+	fmt.Fprintf(&s.body, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"%s/>`+"\n", x, y, r, svgColor(col), svgOpacity("fill-opacity", col))
+}
+
+func (s *svgCanvas) polygon(pts []point, col color.Color) {
+	coords := make([]string, len(pts))
+	for i, p := range pts {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+	}
+	fmt.Fprintf(&s.body, `<polygon points="%s" fill="%s"%s/>`+"\n", strings.Join(coords, " "), svgColor(col), svgOpacity("fill-opacity", col))
+}
+
+func (s *svgCanvas) piece(p game.Piece, x, y, size, alpha float64) {
+	if s.used == nil {
+		s.used = map[game.Piece]bool{}
+	}
+	s.used[p] = true
+	opacity := ""
+	if alpha < 1 {
+		opacity = fmt.Sprintf(` opacity="%.2f"`, alpha)
+	}
+	fmt.Fprintf(&s.body, `<use href="#%s" x="%.1f" y="%.1f" width="%.1f" height="%.1f"%s/>`+"\n", pieceID(p), x, y, size, size, opacity)
+}
+
+func (s *svgCanvas) text(str string, x, y float64, col color.Color) {
+	fmt.Fprintf(&s.body, `<text x="%.1f" y="%.1f" font-family="monospace" font-size="13" fill="%s">%s</text>`+"\n",
+		x, y, svgColor(col), strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(str))
+}
+
+func pieceID(p game.Piece) string {
+	return "piece-" + strings.ToLower(p.String())
+}
+
+// svgColor 返回 #rrggbb；透明度由 svgOpacity 单独给出
+func svgColor(col color.Color) string {
+	c := color.NRGBAModel.Convert(col).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgOpacity(attr string, col color.Color) string {
+	c := color.NRGBAModel.Convert(col).(color.NRGBA)
+	if c.A == 0xFF {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%.2f"`, attr, float64(c.A)/0xFF)
+}
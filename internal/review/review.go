@@ -0,0 +1,163 @@
+// File internal/review/review.go
+package review
+
+/*
+赛后复盘：重放一局记录，对每个跳子局面运行引擎，
+按「实战着法相对最佳着法的失分」给出 best / good / inaccuracy / blunder 评注。
+摆子阶段的着法不参与评注。
+*/
+
+import (
+	"dvonn_go/internal/ai"
+	"dvonn_go/internal/game"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrStopped 复盘被调用方中止
+var ErrStopped = errors.New("review stopped")
+
+// Class 为单步评注等级
+type Class string
+
+const (
+	Best       Class = "best"
+	Good       Class = "good"
+	Inaccuracy Class = "inaccuracy"
+	Blunder    Class = "blunder"
+)
+
+// 失分阈值（与 ai.Evaluate 的量纲一致）
+const (
+	goodLoss       = 15
+	inaccuracyLoss = 50
+)
+
+// Classify 按失分给出评注等级
+func Classify(loss int) Class {
+	switch {
+	case loss <= 0:
+		return Best
+	case loss <= goodLoss:
+		return Good
+	case loss <= inaccuracyLoss:
+		return Inaccuracy
+	default:
+		return Blunder
+	}
+}
+
+// MoveReview 单步跳子的复盘结果
+type MoveReview struct {
+	Ply       int // 在 Record.Moves 中的下标
+	Move      game.JumpMove
+	Best      game.JumpMove
+	Score     int // 实战着法得分（走子方视角）
+	BestScore int
+	Loss      int
+	Class     Class
+}
+
+// Summary 单个玩家的复盘汇总
+type Summary struct {
+	Moves     int
+	Counts    map[Class]int
+	TotalLoss int
+}
+
+// AverageLoss 平均每步失分
+func (s Summary) AverageLoss() float64 {
+	if s.Moves == 0 {
+		return 0
+	}
+	return float64(s.TotalLoss) / float64(s.Moves)
+}
+
+// Report 整局复盘结果；Players 按 Player 下标
+type Report struct {
+	Depth   int
+	Moves   []MoveReview
+	Players [2]Summary
+}
+
+// Review 以 depth 层搜索复盘整局。progress 可为 nil，每完成一个局面回调一次。
+// stop 可为 nil；被置位后在下一个局面前返回 ErrStopped。
+func Review(rec *game.Record, depth int, stop *atomic.Bool, progress func(done, total int)) (*Report, error) {
+	states, moves, err := rec.Replay()
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &Report{Depth: depth}
+	for p := range rep.Players {
+		rep.Players[p].Counts = map[Class]int{}
+	}
+
+	total := 0
+	for _, mv := range moves {
+		if _, ok := mv.(game.JumpMove); ok {
+			total++
+		}
+	}
+
+	done := 0
+	for i, mv := range moves {
+		jm, ok := mv.(game.JumpMove)
+		if !ok {
+			continue
+		}
+		if stop != nil && stop.Load() {
+			return nil, ErrStopped
+		}
+		mr := reviewMove(&states[i], jm, depth)
+		mr.Ply = i
+		rep.Moves = append(rep.Moves, mr)
+
+		s := &rep.Players[jm.Player]
+		s.Moves++
+		s.Counts[mr.Class]++
+		s.TotalLoss += mr.Loss
+
+		done++
+		if progress != nil {
+			progress(done, total)
+		}
+	}
+	return rep, nil
+}
+
+// reviewMove 搜索全部根着法，找出实战着法的得分与最佳着法比较
+func reviewMove(gs *game.GameState, played game.JumpMove, depth int) MoveReview {
+	lines := ai.Search(gs, ai.SearchOptions{Depth: depth, MultiPV: ai.AllMoves})
+	mr := MoveReview{Move: played, Best: played, Class: Best}
+	if len(lines) == 0 {
+		return mr
+	}
+
+	mr.Best, mr.BestScore = lines[0].Move, lines[0].Score
+	mr.Score = mr.BestScore
+	for _, l := range lines {
+		if l.Move == played {
+			mr.Score = l.Score
+			break
+		}
+	}
+	mr.Loss = max(mr.BestScore-mr.Score, 0)
+	mr.Class = Classify(mr.Loss)
+	return mr
+}
+
+// Annotate 返回一份带评注的记录拷贝
+func (rep *Report) Annotate(rec *game.Record) *game.Record {
+	out := *rec
+	out.Moves = append([]game.RecordEntry(nil), rec.Moves...)
+	for _, mr := range rep.Moves {
+		e := &out.Moves[mr.Ply]
+		e.Annotation = string(mr.Class)
+		e.Loss = mr.Loss
+		if mr.Loss > 0 {
+			e.Best = game.FormatMove(mr.Best)
+		}
+	}
+	return &out
+}
@@ -0,0 +1,394 @@
+// File internal/server/server.go
+package server
+
+/*
+HTTP/JSON 对局服务：在内存中托管多局对局，供网页前端与机器人调用。
+
+	POST   /games                    创建对局 {"mode":"pve","ai":"white","depth":4,"auto":false}
+	GET    /games                    列出对局 id
+	GET    /games/{id}               当前局面
+	DELETE /games/{id}               删除对局 {"token":"..."}
+	GET    /games/{id}/moves         当前行动方的合法着法
+	POST   /games/{id}/moves         走子 {"move":"C3-E3","token":"..."}
+	GET    /games/{id}/wait?since=N  长轮询：局面版本大于 N 时返回（超时返回当前局面）
+	GET    /games/{id}/events        Server-Sent Events：每次局面变化推送一次快照
+	GET    /games/{id}/spectate      观战用 SSE：先推完整着法记录（history），之后逐步推送（move），终局推 end
+	POST   /games/{id}/resign        认输 {"token":"..."}
+
+着法使用标准记谱（摆子 "C3"，跳子 "C3-E3"）。
+
+创建对局的响应在快照之外带有 tokens（{"white":"...","black":"..."}，pve 只含人类一方），
+走子、认输与删除都须出示座位令牌；令牌决定以哪一方行动。
+超过 IdleTimeout 无变化且无人观战的对局在下次创建或列出对局时清除。
+*/
+
+import (
+	"dvonn_go/internal/game"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDepth       = 4
+	maxDepth           = 6
+	pollTimeout        = 30 * time.Second
+	DefaultIdleTimeout = time.Hour
+)
+
+// Server 持有全部对局；零值不可用，请用 New
+type Server struct {
+	// IdleTimeout 对局无变化多久后清除；<= 0 表示不清除。须在开始服务前设置
+	IdleTimeout time.Duration
+
+	mu     sync.Mutex
+	games  map[string]*session
+	nextID int
+	mux    *http.ServeMux
+}
+
+// New 创建服务并注册路由
+func New() *Server {
+	s := &Server{IdleTimeout: DefaultIdleTimeout, games: map[string]*session{}, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /games", s.handleCreate)
+	s.mux.HandleFunc("GET /games", s.handleList)
+	s.mux.HandleFunc("GET /games/{id}", s.withSession(s.handleGet))
+	s.mux.HandleFunc("DELETE /games/{id}", s.withSession(s.handleDelete))
+	s.mux.HandleFunc("GET /games/{id}/moves", s.withSession(s.handleMoves))
+	s.mux.HandleFunc("POST /games/{id}/moves", s.withSession(s.handlePlay))
+	s.mux.HandleFunc("GET /games/{id}/wait", s.withSession(s.handleWait))
+	s.mux.HandleFunc("GET /games/{id}/events", s.withSession(s.handleEvents))
+	s.mux.HandleFunc("GET /games/{id}/spectate", s.withSession(s.handleSpectate))
+	s.mux.HandleFunc("POST /games/{id}/resign", s.withSession(s.handleResign))
+	return s
+}
+
+// Count 托管中的对局数
+func (s *Server) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.games)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// pruneLocked 清除空闲过久的对局
+func (s *Server) pruneLocked(now time.Time) {
+	if s.IdleTimeout <= 0 {
+		return
+	}
+	for id, sess := range s.games {
+		if sess.idle(now, s.IdleTimeout) {
+			delete(s.games, id)
+			sess.close()
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// 请求体
+// -----------------------------------------------------------------------------
+
+type createRequest struct {
+	Mode  string `json:"mode"`  // pvp / pve，默认 pve
+	AI    string `json:"ai"`    // pve 时 AI 执子方：white / black，默认 white
+	Depth int    `json:"depth"` // AI 搜索深度，默认 4
+	Auto  bool   `json:"auto"`  // 自动完成摆子阶段
+}
+
+func (req *createRequest) normalize() error {
+	if req.Mode == "" {
+		req.Mode = "pve"
+	}
+	if req.Mode != "pvp" && req.Mode != "pve" {
+		return fmt.Errorf("unknown mode %q", req.Mode)
+	}
+	if req.AI == "" {
+		req.AI = "white"
+	}
+	if _, err := parsePlayer(req.AI); err != nil {
+		return err
+	}
+	if req.Depth == 0 {
+		req.Depth = defaultDepth
+	}
+	if req.Depth < 1 || req.Depth > maxDepth {
+		return fmt.Errorf("depth must be between 1 and %d", maxDepth)
+	}
+	return nil
+}
+
+func (req *createRequest) aiPlayer() game.Player {
+	p, _ := parsePlayer(req.AI)
+	return p
+}
+
+type moveRequest struct {
+	Move  string `json:"move"`
+	Token string `json:"token"`
+}
+
+type tokenRequest struct {
+	Token string `json:"token"`
+}
+
+// createResponse 新对局的快照及各座位令牌
+type createResponse struct {
+	snapshot
+	Tokens map[string]string `json:"tokens"`
+}
+
+func parsePlayer(s string) (game.Player, error) {
+	switch s {
+	case "white":
+		return game.PWhite, nil
+	case "black":
+		return game.PBlack, nil
+	default:
+		return 0, fmt.Errorf("unknown player %q", s)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// 处理函数
+// -----------------------------------------------------------------------------
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	// 空请求体（含分块传输的空体）按默认参数创建
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := req.normalize(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.pruneLocked(time.Now())
+	s.nextID++
+	id := strconv.Itoa(s.nextID)
+	sess := newSession(id, req)
+	s.games[id] = sess
+	s.mu.Unlock()
+
+	w.Header().Set("Location", "/games/"+id)
+	writeJSON(w, http.StatusCreated, createResponse{snapshot: sess.snapshot(), Tokens: sess.tokenJSON()})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	s.pruneLocked(time.Now())
+	ids := make([]string, 0, len(s.games))
+	for id := range s.games {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, map[string][]string{"games": ids})
+}
+
+// withSession 按路径中的 {id} 找到对局
+func (s *Server) withSession(h func(http.ResponseWriter, *http.Request, *session)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		sess, ok := s.games[r.PathValue("id")]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("no such game"))
+			return
+		}
+		h(w, r, sess)
+	}
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, _ *http.Request, sess *session) {
+	writeJSON(w, http.StatusOK, sess.snapshot())
+}
+
+// handleDelete 任一方出示令牌即可删除对局；等待中的连接随之结束
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request, sess *session) {
+	var req tokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, ok := sess.seat(req.Token); !ok {
+		writeError(w, http.StatusForbidden, errBadToken)
+		return
+	}
+	s.mu.Lock()
+	delete(s.games, sess.id)
+	s.mu.Unlock()
+	sess.close()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMoves(w http.ResponseWriter, _ *http.Request, sess *session) {
+	writeJSON(w, http.StatusOK, map[string][]string{"moves": sess.legalMoves()})
+}
+
+func (s *Server) handlePlay(w http.ResponseWriter, r *http.Request, sess *session) {
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	p, ok := sess.seat(req.Token)
+	if !ok {
+		writeError(w, http.StatusForbidden, errBadToken)
+		return
+	}
+	snap, err := sess.play(p, req.Move)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, snap)
+	case errors.Is(err, game.MoveParseError):
+		writeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, errNotYourTurn), errors.Is(err, errGameOver):
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusUnprocessableEntity, err)
+	}
+}
+
+// handleResign 出示令牌的一方认输
+func (s *Server) handleResign(w http.ResponseWriter, r *http.Request, sess *session) {
+	var req tokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	p, ok := sess.seat(req.Token)
+	if !ok {
+		writeError(w, http.StatusForbidden, errBadToken)
+		return
+	}
+	snap, err := sess.resign(p)
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, snap)
+}
+
+// handleWait 长轮询：since 之后有新版本立即返回，否则等到变化或超时
+func (s *Server) handleWait(w http.ResponseWriter, r *http.Request, sess *session) {
+	since, err := strconv.Atoi(r.URL.Query().Get("since"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("since must be a version number"))
+		return
+	}
+
+	timeout := time.NewTimer(pollTimeout)
+	defer timeout.Stop()
+	for {
+		snap, changed := sess.watch()
+		if snap.Version > since || snap.Over {
+			writeJSON(w, http.StatusOK, snap)
+			return
+		}
+		select {
+		case <-changed:
+		case <-timeout.C:
+			writeJSON(w, http.StatusOK, snap)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleEvents 以 SSE 推送快照：连接时先推一次，之后每次变化推一次，终局后关闭
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request, sess *session) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		snap, changed := sess.watch()
+		data, _ := json.Marshal(snap)
+		fmt.Fprintf(w, "id: %d\nevent: state\ndata: %s\n\n", snap.Version, data)
+		flusher.Flush()
+		if snap.Over {
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleSpectate 观战流：晚到的观众先收到完整记录（可快速重放追上），之后每步一条 move 事件
+func (s *Server) handleSpectate(w http.ResponseWriter, r *http.Request, sess *session) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	sess.addSpectator(1)
+	defer sess.addSpectator(-1)
+
+	snap, changed := sess.watch()
+	data, _ := json.Marshal(map[string][]string{"moves": snap.Moves})
+	fmt.Fprintf(w, "event: history\ndata: %s\n\n", data)
+	sent := len(snap.Moves)
+	for {
+		for ; sent < len(snap.Moves); sent++ {
+			data, _ := json.Marshal(map[string]any{"ply": sent + 1, "move": snap.Moves[sent]})
+			fmt.Fprintf(w, "id: %d\nevent: move\ndata: %s\n\n", sent+1, data)
+		}
+		if snap.Over {
+			data, _ := json.Marshal(snap)
+			fmt.Fprintf(w, "event: end\ndata: %s\n\n", data)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+		snap, changed = sess.watch()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// 响应
+// -----------------------------------------------------------------------------
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// do 发送请求并把 JSON 响应解到 out（out 为 nil 时忽略响应体），返回状态码
+func do(t *testing.T, method, url string, body, out any) int {
+	t.Helper()
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// newGame 创建一局人人对战，返回地址与令牌
+func newGame(t *testing.T, ts *httptest.Server) (string, createResponse) {
+	t.Helper()
+	var created createResponse
+	if code := do(t, "POST", ts.URL+"/games", createRequest{Mode: "pvp"}, &created); code != http.StatusCreated {
+		t.Fatalf("create: status %d", code)
+	}
+	if created.ID == "" || created.Tokens["white"] == "" || created.Tokens["black"] == "" {
+		t.Fatalf("create: %+v", created)
+	}
+	return ts.URL + "/games/" + created.ID, created
+}
+
+// TestCreateBody 无请求体或分块传输的空体都按默认参数创建，坏 JSON 返回 400
+func TestCreateBody(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		name string
+		body io.Reader
+		want int
+	}{
+		{"no body", nil, http.StatusCreated},
+		{"chunked empty body", io.MultiReader(), http.StatusCreated},
+		{"chunked body", io.MultiReader(strings.NewReader(`{"mode":"pvp"}`)), http.StatusCreated},
+		{"bad json", strings.NewReader(`{"mode":`), http.StatusBadRequest},
+	} {
+		resp, err := http.Post(ts.URL+"/games", "application/json", tc.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, resp.StatusCode, tc.want)
+		}
+	}
+}
+
+func TestPlay(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, created := newGame(t, ts)
+
+	var snap snapshot
+	if code := do(t, "GET", url, nil, &snap); code != http.StatusOK || snap.Version != 0 || snap.Phase != 1 {
+		t.Fatalf("get: status %d, %+v", code, snap)
+	}
+	var moves map[string][]string
+	if code := do(t, "GET", url+"/moves", nil, &moves); code != http.StatusOK || len(moves["moves"]) != 49 {
+		t.Fatalf("moves: status %d, %d moves", code, len(moves["moves"]))
+	}
+
+	mover, waiter := created.Tokens[snap.ToMove], created.Tokens["white"]
+	if snap.ToMove == "white" {
+		waiter = created.Tokens["black"]
+	}
+	move := moves["moves"][0]
+	for _, tc := range []struct {
+		name string
+		req  moveRequest
+		want int
+	}{
+		{"no token", moveRequest{Move: move}, http.StatusForbidden},
+		{"wrong token", moveRequest{Move: move, Token: "nope"}, http.StatusForbidden},
+		{"other seat", moveRequest{Move: move, Token: waiter}, http.StatusConflict},
+		{"unparsable", moveRequest{Move: "Z9", Token: mover}, http.StatusBadRequest},
+	} {
+		if code := do(t, "POST", url+"/moves", tc.req, nil); code != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, code, tc.want)
+		}
+	}
+
+	if code := do(t, "POST", url+"/moves", moveRequest{Move: move, Token: mover}, &snap); code != http.StatusOK {
+		t.Fatalf("play: status %d", code)
+	}
+	if snap.Version != 1 || len(snap.Moves) != 1 || snap.Moves[0] != move || len(snap.Board) != 1 {
+		t.Fatalf("after play: %+v", snap)
+	}
+
+	// 已落子的格子不能再摆
+	code := do(t, "POST", url+"/moves", moveRequest{Move: move, Token: created.Tokens[snap.ToMove]}, nil)
+	if code < 400 || code >= 500 {
+		t.Errorf("illegal move: status %d, want 4xx", code)
+	}
+}
+
+func TestWait(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, created := newGame(t, ts)
+
+	done := make(chan snapshot)
+	go func() {
+		var snap snapshot
+		if resp, err := http.Get(url + "/wait?since=0"); err == nil {
+			_ = json.NewDecoder(resp.Body).Decode(&snap)
+			resp.Body.Close()
+		}
+		done <- snap
+	}()
+	select {
+	case snap := <-done:
+		t.Fatalf("wait returned before any change: %+v", snap)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var snap snapshot
+	do(t, "GET", url, nil, &snap)
+	do(t, "POST", url+"/moves", moveRequest{Move: "C3", Token: created.Tokens[snap.ToMove]}, nil)
+	select {
+	case snap := <-done:
+		if snap.Version != 1 {
+			t.Errorf("wait: version %d, want 1", snap.Version)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("wait not woken by move")
+	}
+
+	// since 已落后时立即返回
+	if code := do(t, "GET", url+"/wait?since=0", nil, &snap); code != http.StatusOK || snap.Version != 1 {
+		t.Errorf("stale wait: status %d, version %d", code, snap.Version)
+	}
+	if code := do(t, "GET", url+"/wait?since=x", nil, nil); code != http.StatusBadRequest {
+		t.Errorf("bad since: status %d", code)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, _ := newGame(t, ts)
+
+	resp, err := http.Get(url + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type %q", ct)
+	}
+
+	event, data := readEvent(bufio.NewScanner(resp.Body))
+	if event != "state" {
+		t.Fatalf("event %q, want state", event)
+	}
+	var snap snapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil || snap.Version != 0 {
+		t.Fatalf("event data %q: %v", data, err)
+	}
+}
+
+// readEvent 读出下一条 SSE 事件的类型与数据
+func readEvent(sc *bufio.Scanner) (event, data string) {
+	for sc.Scan() && sc.Text() != "" {
+		if v, ok := strings.CutPrefix(sc.Text(), "event: "); ok {
+			event = v
+		}
+		if v, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+			data = v
+		}
+	}
+	return event, data
+}
+
+// TestSpectate 晚到的观众先收到完整记录，再逐步收到 move，终局收到 end；观众没有令牌，不能走子
+func TestSpectate(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, created := newGame(t, ts)
+
+	play := func(move string) {
+		t.Helper()
+		var snap snapshot
+		do(t, "GET", url, nil, &snap)
+		if code := do(t, "POST", url+"/moves", moveRequest{Move: move, Token: created.Tokens[snap.ToMove]}, nil); code != http.StatusOK {
+			t.Fatalf("play %s: status %d", move, code)
+		}
+	}
+	play("C3")
+	play("D3")
+
+	resp, err := http.Get(url + "/spectate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	sc := bufio.NewScanner(resp.Body)
+
+	event, data := readEvent(sc)
+	var history map[string][]string
+	if err := json.Unmarshal([]byte(data), &history); err != nil || event != "history" {
+		t.Fatalf("first event %q %q: %v", event, data, err)
+	}
+	if moves := history["moves"]; len(moves) != 2 || moves[0] != "C3" || moves[1] != "D3" {
+		t.Fatalf("history %v, want [C3 D3]", moves)
+	}
+
+	if code := do(t, "POST", url+"/moves", moveRequest{Move: "E3"}, nil); code != http.StatusForbidden {
+		t.Errorf("move without a seat token: status %d", code)
+	}
+
+	play("E3")
+	event, data = readEvent(sc)
+	var mv struct {
+		Ply  int    `json:"ply"`
+		Move string `json:"move"`
+	}
+	if err := json.Unmarshal([]byte(data), &mv); err != nil || event != "move" || mv.Ply != 3 || mv.Move != "E3" {
+		t.Fatalf("move event %q %q: %v", event, data, err)
+	}
+
+	do(t, "POST", url+"/resign", tokenRequest{Token: created.Tokens["white"]}, nil)
+	event, data = readEvent(sc)
+	var end snapshot
+	if err := json.Unmarshal([]byte(data), &end); err != nil || event != "end" || !end.Over || end.Result != "black" {
+		t.Fatalf("end event %q %q: %v", event, data, err)
+	}
+}
+
+func TestResign(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, created := newGame(t, ts)
+
+	if code := do(t, "POST", url+"/resign", tokenRequest{}, nil); code != http.StatusForbidden {
+		t.Errorf("resign without token: status %d", code)
+	}
+	var snap snapshot
+	if code := do(t, "POST", url+"/resign", tokenRequest{Token: created.Tokens["black"]}, &snap); code != http.StatusOK {
+		t.Fatalf("resign: status %d", code)
+	}
+	if !snap.Over || snap.Resigned != "black" || snap.Result != "white" {
+		t.Fatalf("after resign: %+v", snap)
+	}
+	if code := do(t, "POST", url+"/resign", tokenRequest{Token: created.Tokens["white"]}, nil); code != http.StatusConflict {
+		t.Errorf("resign after game over: status %d", code)
+	}
+}
+
+func TestPVEToken(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	var created createResponse
+	do(t, "POST", ts.URL+"/games", createRequest{Mode: "pve", AI: "white", Depth: 1}, &created)
+	if len(created.Tokens) != 1 || created.Tokens["black"] == "" {
+		t.Fatalf("pve tokens %v, want only black", created.Tokens)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	url, created := newGame(t, ts)
+
+	if code := do(t, "DELETE", url, tokenRequest{Token: "nope"}, nil); code != http.StatusForbidden {
+		t.Errorf("delete with bad token: status %d", code)
+	}
+	if code := do(t, "DELETE", url, tokenRequest{Token: created.Tokens["white"]}, nil); code != http.StatusNoContent {
+		t.Fatalf("delete: status %d", code)
+	}
+	if code := do(t, "GET", url, nil, nil); code != http.StatusNotFound {
+		t.Errorf("get after delete: status %d", code)
+	}
+}
+
+func TestIdleExpiry(t *testing.T) {
+	srv := New()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	url, _ := newGame(t, ts)
+	newGame(t, ts)
+
+	srv.mu.Lock()
+	stale := srv.games["1"]
+	srv.mu.Unlock()
+	stale.mu.Lock()
+	stale.active = time.Now().Add(-srv.IdleTimeout - time.Second)
+	stale.mu.Unlock()
+
+	var list map[string][]string
+	do(t, "GET", ts.URL+"/games", nil, &list)
+	if ids := list["games"]; len(ids) != 1 || ids[0] != "2" {
+		t.Fatalf("games after expiry: %v", ids)
+	}
+	if code := do(t, "GET", url, nil, nil); code != http.StatusNotFound {
+		t.Errorf("get expired game: status %d", code)
+	}
+	if snap := stale.snapshot(); !snap.Over || !snap.Closed {
+		t.Errorf("expired session not closed: %+v", snap)
+	}
+}
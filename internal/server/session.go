@@ -0,0 +1,335 @@
+// File internal/server/session.go
+package server
+
+import (
+	"crypto/rand"
+	"dvonn_go/internal/ai"
+	"dvonn_go/internal/game"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errNotYourTurn = errors.New("it is not your turn")
+	errGameOver    = errors.New("game is over")
+	errBadToken    = errors.New("missing or invalid seat token")
+)
+
+// session 一局托管中的对局；所有字段由 mu 保护
+type session struct {
+	id    string
+	mode  string // "pvp" 或 "pve"
+	aiPl  game.Player
+	depth int
+
+	mu       sync.Mutex
+	state    game.GameState
+	record   *game.Record
+	version  int           // 每次局面变化 +1
+	changed  chan struct{} // 局面变化时关闭并换新，用于广播
+	resigned *game.Player
+	closed   bool      // 已删除或过期，等待者随之退出
+	active   time.Time // 最近一次创建或局面变化的时间
+
+	tokens     map[game.Player]string // 人类执子方的座位令牌，走子与认输时校验
+	spectators int                    // 正在观战的连接数
+}
+
+func newSession(id string, req createRequest) *session {
+	gs := game.StartState()
+	if req.Auto {
+		gs = game.FillPhase1Auto(&gs)
+	}
+	s := &session{
+		id:      id,
+		mode:    req.Mode,
+		aiPl:    req.aiPlayer(),
+		depth:   req.Depth,
+		state:   gs,
+		record:  game.NewRecord(&gs, req.Mode),
+		changed: make(chan struct{}),
+		active:  time.Now(),
+		tokens:  map[game.Player]string{},
+	}
+	for _, p := range []game.Player{game.PWhite, game.PBlack} {
+		if s.mode == "pvp" || p != s.aiPl {
+			s.tokens[p] = newToken()
+		}
+	}
+	s.mu.Lock()
+	s.maybeAILocked()
+	s.mu.Unlock()
+	return s
+}
+
+// newToken 生成一个随机座位令牌
+func newToken() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// seat 返回令牌对应的执子方
+func (s *session) seat(token string) (game.Player, bool) {
+	if token == "" {
+		return 0, false
+	}
+	for p, t := range s.tokens {
+		if t == token {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// tokenJSON 创建对局时交给客户端的令牌，以执子方为键
+func (s *session) tokenJSON() map[string]string {
+	out := map[string]string{}
+	for p, t := range s.tokens {
+		out[lower(p)] = t
+	}
+	return out
+}
+
+// over 终局、已认输或已关闭
+func (s *session) overLocked() bool {
+	return s.resigned != nil || s.closed || game.IsGameOver(&s.state)
+}
+
+func (s *session) aiTurnLocked() bool {
+	return s.mode == "pve" && !s.overLocked() && game.ToMove(&s.state) == s.aiPl
+}
+
+// play 解析并执行执子方 p 的一步着法
+func (s *session) play(p game.Player, text string) (snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.overLocked() {
+		return s.snapshotLocked(), errGameOver
+	}
+	if game.ToMove(&s.state) != p {
+		return s.snapshotLocked(), errNotYourTurn
+	}
+	mv, err := game.ParseMove(text, &s.state)
+	if err == nil {
+		err = game.Play(&s.state, mv)
+	}
+	if err != nil {
+		return s.snapshotLocked(), err
+	}
+	s.record.Add(mv)
+	s.bumpLocked()
+	s.maybeAILocked()
+	return s.snapshotLocked(), nil
+}
+
+// resign 玩家 p 认输
+func (s *session) resign(p game.Player) (snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overLocked() {
+		return s.snapshotLocked(), errGameOver
+	}
+	s.resigned = &p
+	s.bumpLocked()
+	return s.snapshotLocked(), nil
+}
+
+// close 删除或过期时调用：结束对局并唤醒所有等待者
+func (s *session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		s.bumpLocked()
+	}
+}
+
+// idle 无人观战且自 active 起超过 ttl 未变化
+func (s *session) idle(now time.Time, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.spectators == 0 && now.Sub(s.active) > ttl
+}
+
+// maybeAILocked 轮到 AI 时在后台思考，完成后落子并广播
+func (s *session) maybeAILocked() {
+	if !s.aiTurnLocked() {
+		return
+	}
+	gs := s.state
+	gs.Board = s.state.Board.Clone()
+	version := s.version
+
+	go func() {
+		var mv game.Move
+		if gs.Phase == game.Phase1 {
+			mv = ai.ChoosePlacement(&gs)
+		} else {
+			mv = ai.SearchBestMove(&gs, s.depth)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// 思考期间局面已变（如认输）则放弃
+		if s.version != version || game.Play(&s.state, mv) != nil {
+			return
+		}
+		s.record.Add(mv)
+		s.bumpLocked()
+		s.maybeAILocked()
+	}()
+}
+
+// addSpectator 观众数增减 delta；不改变版本号
+func (s *session) addSpectator(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spectators += delta
+}
+
+// bumpLocked 版本号 +1 并唤醒所有等待者
+func (s *session) bumpLocked() {
+	s.version++
+	s.active = time.Now()
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// watch 返回当前快照及下一次变化时会被关闭的通道
+func (s *session) watch() (snapshot, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshotLocked(), s.changed
+}
+
+func (s *session) snapshot() snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshotLocked()
+}
+
+// legalMoves 当前行动方的全部合法着法（记谱）
+func (s *session) legalMoves() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []string{}
+	if s.overLocked() {
+		return out
+	}
+	if s.state.Phase == game.Phase1 {
+		game.ForEachPlayable(func(c game.Coordinate) {
+			if st := s.state.Board.Cells[c.X][c.Y]; st == nil || len(*st) == 0 {
+				out = append(out, game.FormatCoord(c))
+			}
+		})
+		return out
+	}
+	pl := game.TurnStateToPlayer(s.state.Turn)
+	for _, mv := range game.GetPossibleMoves(&s.state.Board) {
+		if jm, ok := mv.(game.JumpMove); ok && jm.Player == pl {
+			out = append(out, game.FormatMove(jm))
+		}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// JSON 快照
+// -----------------------------------------------------------------------------
+
+type cellJSON struct {
+	Cell  string `json:"cell"`
+	Stack string `json:"stack"` // 自顶向下，如 "WBR"
+}
+
+type snapshot struct {
+	ID         string     `json:"id"`
+	Version    int        `json:"version"`
+	Mode       string     `json:"mode"`
+	AI         string     `json:"ai,omitempty"`
+	Phase      int        `json:"phase"`
+	Turn       string     `json:"turn"`
+	ToMove     string     `json:"toMove,omitempty"`
+	NextPiece  string     `json:"nextPiece,omitempty"`
+	Board      []cellJSON `json:"board"`
+	Discarded  int        `json:"discarded"`
+	White      int        `json:"white"`
+	Black      int        `json:"black"`
+	Moves      []string   `json:"moves"`
+	Over       bool       `json:"over"`
+	Result     string     `json:"result,omitempty"` // white / black / draw
+	Resigned   string     `json:"resigned,omitempty"`
+	Closed     bool       `json:"closed,omitempty"` // 已删除或过期
+	Spectators int        `json:"spectators"`
+}
+
+func (s *session) snapshotLocked() snapshot {
+	gs := &s.state
+	snap := snapshot{
+		ID:         s.id,
+		Version:    s.version,
+		Mode:       s.mode,
+		Phase:      int(gs.Phase) + 1,
+		Turn:       string(gs.Turn),
+		Board:      []cellJSON{},
+		Discarded:  len(gs.Board.Discard),
+		Moves:      make([]string, len(s.record.Moves)),
+		Over:       s.overLocked(),
+		Closed:     s.closed,
+		Spectators: s.spectators,
+	}
+	if s.mode == "pve" {
+		snap.AI = lower(s.aiPl)
+	}
+	for i, e := range s.record.Moves {
+		snap.Moves[i] = e.Move
+	}
+	game.ForEachPlayable(func(c game.Coordinate) {
+		st := gs.Board.Cells[c.X][c.Y]
+		if st == nil || len(*st) == 0 {
+			return
+		}
+		snap.Board = append(snap.Board, cellJSON{Cell: game.FormatCoord(c), Stack: st.String()})
+		switch (*st)[0] {
+		case game.White:
+			snap.White += len(*st)
+		case game.Black:
+			snap.Black += len(*st)
+		}
+	})
+
+	switch {
+	case s.resigned != nil:
+		snap.Resigned = lower(*s.resigned)
+		snap.Result = lower(opponent(*s.resigned))
+	case game.IsGameOver(gs):
+		snap.Result = "draw"
+		if w := game.Winner(gs); w != nil {
+			snap.Result = lower(*w)
+		}
+	case s.closed:
+	default:
+		snap.ToMove = lower(game.ToMove(&s.state))
+		if gs.Phase == game.Phase1 {
+			snap.NextPiece = strings.ToLower(game.NextPiece(gs).String())
+		}
+	}
+	return snap
+}
+
+func lower(p game.Player) string { return strings.ToLower(p.String()) }
+
+func opponent(p game.Player) game.Player {
+	if p == game.PWhite {
+		return game.PBlack
+	}
+	return game.PWhite
+}
@@ -0,0 +1,237 @@
+// File internal/ui/console/cli.go
+package console
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"dvonn_go/internal/ai"
+	game "dvonn_go/internal/game"
+)
+
+// Config 终端对局参数
+type Config struct {
+	Mode     string      // "pvp" 或 "pve"
+	AIPlayer game.Player // pve 时由 AI 控制的一方
+	Depth    int         // AI 搜索深度
+	Auto     bool        // 自动完成摆子阶段
+	Unicode  bool        // 棋盘使用 Unicode 线条
+	Color    bool        // 棋盘使用 ANSI 颜色
+}
+
+var summary1 = `Welcome to DVONN.
+
+In Phase 1, you must place your pieces one by one on
+unoccupied spaces on the game board. White will begin
+and players will alternate placing. Place your red
+pieces first, and then proceed to place your normal pieces.
+White has two red pieces and Black has one red piece.
+
+To place a piece, use the command <coord>, for example, "C3".
+Type "help" for all commands.`
+
+var summary2 = `
+In Phase 2, you may move any stack of height n with your color
+piece on top to be on top of another stack that is exactly
+n hops away. Be careful, though! Any stacks that are not
+in a connected component with a red piece will be discarded.
+Whoever controls the most pieces at the end wins!
+
+To jump, use the command <start> "to" <end>, for example "C3 to D3"
+(or simply "C3-D3").`
+
+var helpText = `Commands:
+  <coord>            place a piece, e.g. C3
+  <from>-<to>        jump a stack, e.g. C3-D3 (also "C3 D3" or "C3 to D3")
+  moves              list your legal jumps
+  board              print the board again
+  save <file>        save the game record as JSON
+  help               show this help
+  quit               leave the game`
+
+// Run 在终端中进行一整局（摆子 + 跳子），直到终局、quit 或输入结束
+func Run(in io.Reader, out io.Writer, cfg Config) error {
+	gs := game.StartState()
+	if cfg.Auto {
+		gs = game.FillPhase1Auto(&gs)
+	}
+	rec := game.NewRecord(&gs, cfg.Mode)
+	sc := bufio.NewScanner(in)
+
+	fmt.Fprintln(out, summary1)
+	shownPhase2 := false
+	redraw := true
+	var last []game.Coordinate // 上一步涉及的格子，绘制时高亮
+
+	for !game.IsGameOver(&gs) {
+		if gs.Phase == game.Phase2 && !shownPhase2 {
+			fmt.Fprintln(out, summary2)
+			shownPhase2 = true
+		}
+		if redraw {
+			printStatus(out, &gs, cfg.textOptions(last))
+			redraw = false
+		}
+
+		// AI 回合
+		if cfg.Mode == "pve" && game.ToMove(&gs) == cfg.AIPlayer {
+			mv := aiMove(&gs, cfg.Depth)
+			if err := game.Play(&gs, mv); err != nil {
+				return fmt.Errorf("ai move %s: %w", game.FormatMove(mv), err)
+			}
+			rec.Add(mv)
+			fmt.Fprintf(out, "%v plays %s\n", cfg.AIPlayer, game.FormatMove(mv))
+			last = moveCells(mv)
+			redraw = true
+			continue
+		}
+
+		fmt.Fprintf(out, "%s> ", prompt(&gs))
+		if !sc.Scan() {
+			fmt.Fprintln(out)
+			return sc.Err()
+		}
+		line := strings.TrimSpace(sc.Text())
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToLower(cmd) {
+		case "":
+			continue
+		case "quit", "exit":
+			return nil
+		case "help":
+			fmt.Fprintln(out, helpText)
+			continue
+		case "board":
+			redraw = true
+			continue
+		case "moves":
+			printMoves(out, &gs)
+			continue
+		case "save":
+			if err := rec.Save(strings.TrimSpace(arg)); err != nil {
+				fmt.Fprintln(out, "Save failed:", err)
+			} else {
+				fmt.Fprintln(out, "Saved.")
+			}
+			continue
+		}
+
+		mv, err := game.ParseMove(line, &gs)
+		if err == nil {
+			err = game.Play(&gs, mv)
+		}
+		if err != nil {
+			printErr(out, err)
+			continue
+		}
+		rec.Add(mv)
+		last = moveCells(mv)
+		redraw = true
+	}
+
+	printStatus(out, &gs, cfg.textOptions(last))
+	printResult(out, &gs)
+	return nil
+}
+
+// textOptions 按配置生成棋盘绘制参数
+func (cfg Config) textOptions(highlight []game.Coordinate) game.TextOptions {
+	return game.TextOptions{Unicode: cfg.Unicode, Color: cfg.Color, Labels: true, Highlight: highlight}
+}
+
+// moveCells 返回一步棋涉及的格子
+func moveCells(mv game.Move) []game.Coordinate {
+	switch m := mv.(type) {
+	case game.PlaceMove:
+		return []game.Coordinate{m.At}
+	case game.JumpMove:
+		return []game.Coordinate{m.From, m.To}
+	}
+	return nil
+}
+
+// aiMove 摆子阶段随机落子，跳子阶段搜索
+func aiMove(gs *game.GameState, depth int) game.Move {
+	if gs.Phase == game.Phase1 {
+		return ai.ChoosePlacement(gs)
+	}
+	return ai.SearchBestMove(gs, depth)
+}
+
+func prompt(gs *game.GameState) string {
+	if gs.Phase == game.Phase1 {
+		return fmt.Sprintf("%v, place a %s piece", game.PlacingPlayer(gs), strings.ToLower(game.NextPiece(gs).String()))
+	}
+	switch gs.Turn {
+	case game.MoveWhite:
+		return "White move"
+	case game.MoveBlack:
+		return "Black move"
+	default:
+		return "UnknownTurnState"
+	}
+}
+
+func printErr(w io.Writer, err error) {
+	switch err {
+	case game.MoveParseError:
+		fmt.Fprintln(w, "You entered a malformed move.")
+	case game.InvalidMove:
+		fmt.Fprintln(w, "Your move was incorrect.")
+	default:
+		fmt.Fprintln(w, "Unknown error.")
+	}
+}
+
+func printStatus(w io.Writer, gs *game.GameState, opts game.TextOptions) {
+	fmt.Fprintln(w)
+	PrintBoard(w, &gs.Board, opts)
+	black, white := scores(&gs.Board)
+	fmt.Fprintf(w, "Black: %d  White: %d  Discarded: %d\n", black, white, len(gs.Board.Discard))
+}
+
+func printMoves(w io.Writer, gs *game.GameState) {
+	if gs.Phase == game.Phase1 {
+		fmt.Fprintln(w, "Any empty cell.")
+		return
+	}
+	pl := game.TurnStateToPlayer(gs.Turn)
+	var list []string
+	for _, mv := range game.GetPossibleMoves(&gs.Board) {
+		if jm, ok := mv.(game.JumpMove); ok && jm.Player == pl {
+			list = append(list, game.FormatMove(jm))
+		}
+	}
+	fmt.Fprintln(w, strings.Join(list, " "))
+}
+
+func printResult(w io.Writer, gs *game.GameState) {
+	black, white := scores(&gs.Board)
+	fmt.Fprintf(w, "Game over! White controls %d pieces; Black controls %d pieces.", white, black)
+	switch p := game.Winner(gs); {
+	case p == nil:
+		fmt.Fprintln(w, " It's a draw!")
+	default:
+		fmt.Fprintf(w, " %v wins!\n", *p)
+	}
+}
+
+// scores 统计双方堆顶控制的棋子数
+func scores(b *game.Board) (black, white int) {
+	game.ForEachPlayable(func(c game.Coordinate) {
+		st := b.Cells[c.X][c.Y]
+		if st == nil || len(*st) == 0 {
+			return
+		}
+		switch (*st)[0] {
+		case game.Black:
+			black += len(*st)
+		case game.White:
+			white += len(*st)
+		}
+	})
+	return
+}
@@ -0,0 +1,195 @@
+// File internal/ui/ebiten/accessibility.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// 配色：标准、色盲友好（Okabe–Ito）与高对比度
+// -----------------------------------------------------------------------------
+
+type palette struct {
+	name        string
+	line        color.RGBA // 网格线
+	circle      color.RGBA // 空格
+	selection   color.RGBA // 可移动 / 已选中（highlightBlue）
+	destination color.RGBA // 合法终点（highlightGreen）
+	cursor      color.RGBA // 键盘光标
+	discard     color.RGBA // 预演中将被移除的堆
+}
+
+// paletteName 当前配色
+var paletteName = "standard"
+
+var palettes = []palette{
+	{"standard",
+		color.RGBA{0x44, 0x44, 0x44, 0xFF}, color.RGBA{0xB0, 0xC4, 0xDE, 0xFF},
+		color.RGBA{0x00, 0x66, 0xFF, 0xFF}, color.RGBA{0x00, 0xCC, 0x66, 0xFF}, color.RGBA{0xFF, 0xD7, 0x00, 0xFF},
+		color.RGBA{0xE0, 0x30, 0x30, 0xFF}},
+	{"colour-blind",
+		color.RGBA{0x44, 0x44, 0x44, 0xFF}, color.RGBA{0xB0, 0xC4, 0xDE, 0xFF},
+		color.RGBA{0x00, 0x72, 0xB2, 0xFF}, color.RGBA{0xE6, 0x9F, 0x00, 0xFF}, color.RGBA{0xCC, 0x79, 0xA7, 0xFF},
+		color.RGBA{0xD5, 0x5E, 0x00, 0xFF}},
+	{"high-contrast",
+		color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}, color.RGBA{0x60, 0x60, 0x60, 0xFF},
+		color.RGBA{0xFF, 0xFF, 0x00, 0xFF}, color.RGBA{0x00, 0xFF, 0xFF, 0xFF}, color.RGBA{0xFF, 0x00, 0xFF, 0xFF},
+		color.RGBA{0xFF, 0x30, 0x30, 0xFF}},
+}
+
+// PaletteNames 返回可选配色的名称
+func PaletteNames() []string {
+	names := make([]string, len(palettes))
+	for i, p := range palettes {
+		names[i] = p.name
+	}
+	return names
+}
+
+// SetPalette 切换配色；画布已建立时重画棋盘背景
+func SetPalette(name string) error {
+	for _, p := range palettes {
+		if p.name != name {
+			continue
+		}
+		lineColor, circleColor = p.line, p.circle
+		if p.name == "standard" {
+			// 标准配色沿用主题的网格与格子颜色
+			lineColor, circleColor = theme.line, theme.circle
+		}
+		paletteName = name
+		highlightBlue, highlightGreen = p.selection, p.destination
+		kbCursorColor, discardColor = p.cursor, p.discard
+		if screenW > 0 {
+			relayout(screenW, screenH)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown palette %q (want %s)", name, strings.Join(PaletteNames(), ", "))
+}
+
+// -----------------------------------------------------------------------------
+// 文字播报：每步着法与键盘光标所在的格子，供读屏软件使用
+// -----------------------------------------------------------------------------
+
+// NarrationLog 为 "log" 播报方式写入的文件
+const NarrationLog = "dvonn-narration.log"
+
+var (
+	narrator     io.Writer // nil 时不播报
+	narrationTo  string    // 当前播报目标："" / "stdout" / 文件路径
+	narrationOut *os.File  // 播报到文件时打开的文件
+)
+
+// SetNarration 设置播报目标：""（关闭）、"stdout" 或追加写入的文件路径
+func SetNarration(to string) error {
+	if to == narrationTo {
+		return nil
+	}
+	if narrationOut != nil {
+		narrationOut.Close()
+		narrationOut = nil
+	}
+	narrator, narrationTo = nil, to
+	switch to {
+	case "":
+	case "stdout":
+		narrator = os.Stdout
+	default:
+		f, err := os.OpenFile(to, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			narrationTo = ""
+			return err
+		}
+		narrator, narrationOut = f, f
+	}
+	return nil
+}
+
+func narrate(format string, args ...any) {
+	if narrator != nil {
+		fmt.Fprintf(narrator, format+"\n", args...)
+	}
+}
+
+// narrateMove 播报一步着法及其后果：弃子、下一步轮到谁或终局比分
+func narrateMove(before *game.GameState, mv game.Move, after *game.GameState) {
+	if narrator == nil {
+		return
+	}
+	var b strings.Builder
+	switch m := mv.(type) {
+	case game.PlaceMove:
+		fmt.Fprintf(&b, "%v places a %s piece on %s.", game.PlacingPlayer(before), pieceName(m.Piece), game.FormatCoord(m.At))
+	case game.JumpMove:
+		from := stackAt(&before.Board, m.From)
+		to := stackAt(&after.Board, m.To)
+		fmt.Fprintf(&b, "%v moves the stack of %d from %s to %s, making a stack of %d.",
+			m.Player, len(from), game.FormatCoord(m.From), game.FormatCoord(m.To), len(to))
+		if n := len(after.Board.Discard) - len(before.Board.Discard); n > 0 {
+			fmt.Fprintf(&b, " %d pieces cut off from the DVONN pieces are removed.", n)
+		}
+	}
+	switch {
+	case game.IsGameOver(after):
+		black, white := currentScores(&after.Board)
+		if w := game.Winner(after); w != nil {
+			fmt.Fprintf(&b, " Game over: %v wins, White %d, Black %d.", *w, white, black)
+		} else {
+			fmt.Fprintf(&b, " Game over: draw, %d each.", white)
+		}
+	case after.Phase == game.Phase1:
+		fmt.Fprintf(&b, " %v to place a %s piece.", game.PlacingPlayer(after), pieceName(game.NextPiece(after)))
+	default:
+		fmt.Fprintf(&b, " %v to move.", game.ToMove(after))
+	}
+	narrate("%s", b.String())
+}
+
+// narrateCell 播报键盘光标所在格子的内容及其在当前选择下的作用
+func narrateCell(gs *game.GameState, c game.Coordinate) {
+	if narrator == nil {
+		return
+	}
+	desc := "empty"
+	if st := stackAt(&gs.Board, c); len(st) > 0 {
+		desc = fmt.Sprintf("%s-topped stack of %d", pieceName(st[0]), len(st))
+	}
+	switch {
+	case selected && c == selectedAt:
+		desc += ", selected"
+	case selected && containsCoord(destinations(gs, selectedAt), c):
+		desc += ", legal destination"
+		if p, err := game.PreviewMove(gs, game.JumpMove{Player: game.ToMove(gs), From: selectedAt, To: c}); err == nil && p.Removed() > 0 {
+			desc += fmt.Sprintf(", would remove %d pieces", p.Removed())
+		}
+	case !selected && gs.Phase == game.Phase2 && isMovable(gs, c):
+		desc += ", movable"
+	}
+	narrate("%s: %s", game.FormatCoord(c), desc)
+}
+
+func stackAt(b *game.Board, c game.Coordinate) game.Stack {
+	if st := b.Cells[c.X][c.Y]; st != nil {
+		return *st
+	}
+	return nil
+}
+
+func pieceName(p game.Piece) string {
+	return strings.ToLower(p.String())
+}
+
+func containsCoord(cs []game.Coordinate, c game.Coordinate) bool {
+	for _, x := range cs {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
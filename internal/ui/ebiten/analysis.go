@@ -0,0 +1,143 @@
+// File internal/ui/ebiten/analysis.go
+package ebiten
+
+import (
+	"dvonn_go/internal/ai"
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"math"
+	"strings"
+)
+
+const (
+	analysisDepth   = 4 // 后台分析的最大深度
+	analysisMultiPV = 3 // 显示的候选着法数
+
+	evalBarY = 12 // evalBarX 随画布宽度居中，见 layout.go
+	evalBarW = 400
+	evalBarH = 14
+
+	evalScale = 300.0 // 得分压缩到评估条时的尺度
+	pvMaxLen  = 8     // 每条变例最多显示的步数
+)
+
+var (
+	evalWhiteColor = color.RGBA{0xF0, 0xF0, 0xF0, 0xFF}
+	evalBlackColor = color.RGBA{0x20, 0x20, 0x20, 0xFF}
+	// 第 1/2/3 候选的箭头颜色，依次变淡
+	arrowColors = []color.RGBA{
+		{0xFF, 0x8C, 0x00, 0xE0},
+		{0xFF, 0x8C, 0x00, 0x90},
+		{0xFF, 0x8C, 0x00, 0x50},
+	}
+)
+
+// toggleAnalysis 开关分析模式
+func (g *GameView) toggleAnalysis() {
+	g.analysis = !g.analysis
+	if g.analysis {
+		g.analysisKey = 0
+		g.refreshAnalysis()
+		return
+	}
+	g.analyzer.Stop()
+}
+
+// refreshAnalysis 局面变化时重新启动后台分析
+func (g *GameView) refreshAnalysis() {
+	key := ai.Hash(&g.state.Board, game.TurnStateToPlayer(g.state.Turn)) ^ uint64(g.state.PlaceStep+1)
+	if game.IsGameOver(&g.state) {
+		key = ^key
+	}
+	if key == g.analysisKey {
+		return
+	}
+	g.analysisKey = key
+	g.analyzer.Start(g.state)
+}
+
+// drawAnalysis 绘制评估条、候选箭头和主要变例
+func (g *GameView) drawAnalysis(screen *ebiten.Image) {
+	lines, d := g.analyzer.Lines()
+
+	if g.state.Phase != game.Phase2 {
+		drawTextWithShadow(screen, "Analysis: available in the movement phase", 20, pvTextY, color.Black, color.White)
+		return
+	}
+	if len(lines) == 0 {
+		drawEvalBar(screen, 0)
+		drawTextWithShadow(screen, "Analysis: thinking...", 20, pvTextY, color.Black, color.White)
+		return
+	}
+
+	// 分数统一换算为白方视角
+	stm := game.TurnStateToPlayer(g.state.Turn)
+	white := lines[0].Score
+	if stm == game.PBlack {
+		white = -white
+	}
+	drawEvalBar(screen, white)
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		drawArrow(screen, lines[i].Move.From, lines[i].Move.To, arrowColors[min(i, len(arrowColors)-1)])
+	}
+
+	header := fmt.Sprintf("Analysis  depth %d  eval %+d (White)", d, white)
+	drawTextWithShadow(screen, header, 20, pvTextY, color.Black, color.White)
+	for i, l := range lines {
+		drawTextWithShadow(screen, fmt.Sprintf("%d. %+5d  %s", i+1, l.Score, formatPV(l.PV)), 20, pvTextY+20*(i+1), color.Black, color.White)
+	}
+}
+
+// drawEvalBar 水平评估条：左白右黑，白方占优时白色部分变长
+func drawEvalBar(screen *ebiten.Image, whiteScore int) {
+	frac := 0.5 + 0.5*math.Tanh(float64(whiteScore)/evalScale)
+	wW := float32(frac * evalBarW)
+
+	vector.DrawFilledRect(screen, evalBarX, evalBarY, evalBarW, evalBarH, evalBlackColor, false)
+	vector.DrawFilledRect(screen, evalBarX, evalBarY, wW, evalBarH, evalWhiteColor, false)
+	vector.StrokeRect(screen, evalBarX, evalBarY, evalBarW, evalBarH, 1, lineColor, false)
+	// 中线
+	vector.StrokeLine(screen, evalBarX+evalBarW/2, evalBarY-3, evalBarX+evalBarW/2, evalBarY+evalBarH+3, 1, highlightBlue, false)
+}
+
+// drawArrow 在两个格子之间画带箭头的线段
+func drawArrow(dst *ebiten.Image, from, to game.Coordinate, col color.Color) {
+	fx, fy := coordToScreen(from)
+	tx, ty := coordToScreen(to)
+	dx, dy := tx-fx, ty-fy
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	ux, uy := dx/l, dy/l
+
+	// 箭头停在目标格圆圈边缘
+	r := float64(triangleR) / 3
+	ex, ey := tx-ux*r, ty-uy*r
+	const head = 14.0
+	width := float32(triangleR) / 10
+
+	vector.StrokeLine(dst, float32(fx), float32(fy), float32(ex), float32(ey), width, col, true)
+	for _, s := range []float64{-1, 1} {
+		hx := ex - head*(ux*math.Cos(math.Pi/6)-s*uy*math.Sin(math.Pi/6))
+		hy := ey - head*(uy*math.Cos(math.Pi/6)+s*ux*math.Sin(math.Pi/6))
+		vector.StrokeLine(dst, float32(ex), float32(ey), float32(hx), float32(hy), width, col, true)
+	}
+}
+
+// formatPV 把变例转成记谱文本
+func formatPV(pv []game.JumpMove) string {
+	parts := make([]string, 0, len(pv))
+	for i, m := range pv {
+		if i == pvMaxLen {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, game.FormatMove(m))
+	}
+	return strings.Join(parts, " ")
+}
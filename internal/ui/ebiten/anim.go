@@ -10,19 +10,27 @@ import (
 type Animation struct {
 	Piece    game.Piece
 	From, To game.Coordinate
+	Frames   int // 总帧数，0 为 animFrames；观战追赶进度时用更少的帧
 	frame    int
 }
 
-// 总帧数：越大越慢
-const animFrames = 10
+// 总帧数：越大越慢；设置页的动画速度会修改它
+var animFrames = 10
 
 // 完成？
-func (a *Animation) done() bool { return a.frame >= animFrames }
+func (a *Animation) done() bool { return a.frame >= a.total() }
+
+func (a *Animation) total() int {
+	if a.Frames > 0 {
+		return a.Frames
+	}
+	return animFrames
+}
 
 // Draw 在屏幕上绘制当前帧的棋子
 func (a *Animation) Draw(screen *ebiten.Image) {
 	// 计算从 0 到 1 的 t
-	t := float64(a.frame) / float64(animFrames)
+	t := float64(a.frame) / float64(a.total())
 
 	// 格点 to 屏像素
 	fx, fy := coordToScreen(a.From)
@@ -33,15 +41,7 @@ func (a *Animation) Draw(screen *ebiten.Image) {
 	y := fy + (ty-fy)*t
 
 	// 选图
-	var img *ebiten.Image
-	switch a.Piece {
-	case game.Red:
-		img = imgRed
-	case game.White:
-		img = imgWhite
-	default:
-		img = imgBlack
-	}
+	img := pieceImage(a.Piece)
 
 	// 缩放 & 平移到中心
 	op := &ebiten.DrawImageOptions{}
@@ -2,36 +2,10 @@
 package ebiten
 
 import (
-	"bytes"
-	_ "embed"
-	"github.com/hajimehoshi/ebiten/v2"
-	"image"
-	_ "image/png"
+	"dvonn_go/internal/game"
 )
 
-//go:embed assets/red1.png
-var redPNG []byte
-
-//go:embed assets/white1.png
-var whitePNG []byte
-
-//go:embed assets/black1.png
-var blackPNG []byte
-
+// 当前主题的棋子贴图，默认为内嵌素材；由 SetTheme 切换
 var (
-	imgRed, imgWhite, imgBlack *ebiten.Image
+	imgRed, imgWhite, imgBlack = theme.pieces[game.Red], theme.pieces[game.White], theme.pieces[game.Black]
 )
-
-func init() {
-	imgRed = decode(redPNG)
-	imgWhite = decode(whitePNG)
-	imgBlack = decode(blackPNG)
-}
-func decode(b []byte) *ebiten.Image {
-	//fmt.Println("Decoding image...")
-	img, _, err := image.Decode(bytes.NewReader(b))
-	if err != nil {
-		panic("Failed to decode image: " + err.Error())
-	}
-	return ebiten.NewImageFromImage(img)
-}
@@ -2,11 +2,9 @@ package ebiten
 
 import (
 	"dvonn_go/internal/game"
-	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"image/color"
-	"math"
 )
 
 var (
@@ -16,38 +14,9 @@ var (
 	highlightGreen = color.RGBA{0x00, 0xCC, 0x66, 0xFF}
 )
 
+// boardBG 为预先画好的网格与格子，画布尺寸变化时由 relayout 重画
 var boardBG *ebiten.Image
 
-const (
-	triangleR = 70 //
-
-	offsetX   = 650
-	offsetY   = 360
-	fillColor = 0xDDDDDDFF
-
-	canvasW = 1300
-	canvasH = 700
-)
-
-var triangleH = math.Sqrt(3) * triangleR / 2
-
-func init() {
-
-	boardBG = ebiten.NewImage(canvasW, canvasH)
-
-	forEachCoordinate(func(c game.Coordinate) {
-		drawCircle(boardBG, c)
-		drawCoordinate(boardBG, c)
-	})
-
-	drawGridLines(boardBG)
-}
-
-func drawCoordinate(dst *ebiten.Image, c game.Coordinate) {
-	cx, cy := coordToScreen(c)
-	ebitenutil.DebugPrintAt(dst, fmt.Sprintf("(%d,%d)", c.X, c.Y), int(cx-10), int(cy-10))
-}
-
 func drawCircle(dst *ebiten.Image, c game.Coordinate) {
 	screenX, screenY := coordToScreen(c)
 	radius := float64(triangleR) / 3
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font/basicfont"
 	"image/color"
 	"math"
 )
@@ -16,9 +15,6 @@ const (
 	boardXOff = 300 // ����X��ƫ�ƣ�ȷ����಻���ض�
 	boardYOff = 200 // ����Y��ƫ�ƣ�ȷ���Ϸ������ض�
 )
-const (
-	layerOffsetY = 6 // ÿ�����Ӵ�ֱƫ������
-)
 
 // axial �� ��Ļ����
 // coordToScreen ������ c ת��Ϊ��Ļ�ϵ�λ��
@@ -30,36 +26,37 @@ func coordToScreen(c game.Coordinate) (float64, float64) {
 	y := triangleR * (3.0 / 2 * r)
 
 	// Ӧ��ƫ������ȷ���������̶��ڿɼ�������
-	return offsetX + x, offsetY + y
+	return orient(offsetX+x, offsetY+y)
 }
 
 // drawStack �������Ӷѣ�α3D���+������ע
 func drawStack(b *game.Board, c game.Coordinate, screen *ebiten.Image) {
+	drawStackAlpha(b, c, screen, 1)
+}
+
+// drawStackAlpha 以给定不透明度（0–1）绘制棋子堆，用于淡出效果
+func drawStackAlpha(b *game.Board, c game.Coordinate, screen *ebiten.Image, alpha float32) {
 	stackPtr := b.Cells[c.X][c.Y]
 	if stackPtr == nil || len(*stackPtr) == 0 {
 		return
 	}
-	stack := *stackPtr
 
 	// ����ת��Ϊ��Ļ����
 	x, y := coordToScreen(c)
+	drawStackAt(*stackPtr, x, y, screen, alpha)
+}
+
+// drawStackAt 以 (x, y) 为底层中心绘制棋子堆；拖拽中的堆跟随光标时使用
+func drawStackAt(stack game.Stack, x, y float64, screen *ebiten.Image, alpha float32) {
 	// ��ͼ���Ż�׼����������������ͼ��ͬ��С��
 	scaledSize := float64(triangleR)
 	scale := scaledSize / float64(imgRed.Bounds().Dx())
 
 	// �ӵײ㵽������ƣ�ÿ����ݶ�Ӧ�� Piece ѡ����ͼ
 	for idx := len(stack) - 1; idx >= 0; idx-- {
-		piece := stack[idx]
-		var img *ebiten.Image
-		switch piece {
-		case game.Red:
-			img = imgRed
-		case game.White:
-			img = imgWhite
-		case game.Black:
-			img = imgBlack
-		}
+		img := pieceImage(stack[idx])
 		op := &ebiten.DrawImageOptions{}
+		op.ColorScale.ScaleAlpha(alpha)
 		op.GeoM.Scale(scale, scale)
 		// ���㵱ǰ��Ĵ�ֱƫ��
 		layerOffset := float64(len(stack)-1-idx) * layerOffsetY
@@ -71,9 +68,30 @@ func drawStack(b *game.Board, c game.Coordinate, screen *ebiten.Image) {
 	// �ڶ������Ļ��ƶѸ�����
 	count := fmt.Sprint(len(stack))
 	labelX := int(x) + int(scaledSize)/2 - 4
-	labelY := int(y) - (len(stack)-1)*layerOffsetY + 10
+	labelY := int(y - float64(len(stack)-1)*layerOffsetY + 10)
 	// ��ɫ��Ӱ
-	text.Draw(screen, count, basicfont.Face7x13, labelX, labelY, color.Black)
+	text.Draw(screen, count, uiFace, labelX, labelY, color.Black)
 	// ��ɫǰ��
-	text.Draw(screen, count, basicfont.Face7x13, labelX-1, labelY-1, color.White)
+	text.Draw(screen, count, uiFace, labelX-1, labelY-1, color.White)
+}
+
+// pieceImage 返回棋子对应的贴图
+func pieceImage(p game.Piece) *ebiten.Image {
+	switch p {
+	case game.White:
+		return imgWhite
+	case game.Black:
+		return imgBlack
+	}
+	return imgRed
+}
+
+// drawPieceIcon 以 (x, y) 为中心画一枚边长 size 的棋子，用于面板上的图例
+func drawPieceIcon(screen *ebiten.Image, p game.Piece, x, y, size float64) {
+	img := pieceImage(p)
+	scale := size / float64(img.Bounds().Dx())
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x-size/2, y-size/2)
+	screen.DrawImage(img, op)
 }
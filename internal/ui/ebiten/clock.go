@@ -0,0 +1,95 @@
+// File internal/ui/ebiten/clock.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+	"time"
+)
+
+// TimeControls 菜单中可选的用时规则（见 game.ParseTimeControl）
+var TimeControls = []string{"off", "3m+2s", "5m", "5m+3s", "10m", "10m/3x30s", "20m/5x30s"}
+
+var lowTimeColor = color.RGBA{0xFF, 0x60, 0x60, 0xFF}
+
+// SetTimeControl 为对局装上棋钟；tc 为零值时不计时。联网对局不计时。
+func (g *GameView) SetTimeControl(tc game.TimeControl) {
+	g.clock, g.clockStamp = nil, nil
+	g.record.TimeControl = ""
+	if tc.IsZero() || g.net != nil {
+		return
+	}
+	g.clock = game.NewClock(tc)
+	g.record.TimeControl = tc.String()
+}
+
+// over 终局：无子可走，或一方超时
+func (g *GameView) over() bool {
+	return game.IsGameOver(&g.state) || (g.clock != nil && g.clock.Flagged != nil)
+}
+
+// tickClock 每帧调用：空闲时为行棋方开钟，检查超时，终局后停钟
+func (g *GameView) tickClock() {
+	if g.clock == nil {
+		return
+	}
+	now := time.Now()
+	if g.over() {
+		g.clock.Pause(now)
+		return
+	}
+	if g.clock.Expired(now) {
+		return
+	}
+	if _, running := g.clock.Running(); !running && g.pendingMv == nil && len(g.anims) == 0 {
+		g.clock.Start(game.ToMove(&g.state), now)
+	}
+}
+
+// pressClock 着法确定时停钟；戳记在 play 写入记录时附上
+func (g *GameView) pressClock() {
+	if g.clock == nil {
+		return
+	}
+	if _, running := g.clock.Running(); !running {
+		return
+	}
+	s := g.clock.Press(time.Now())
+	g.clockStamp = &s
+}
+
+// pauseClock 离开对局（如回到菜单）时暂停
+func (g *GameView) pauseClock() {
+	if g.clock != nil {
+		g.clock.Pause(time.Now())
+	}
+}
+
+// drawClocks 在比分右侧显示双方剩余时间，行棋方以 "<" 标出，不足 10 秒时变红
+func (g *GameView) drawClocks(screen *ebiten.Image) {
+	if g.clock == nil {
+		return
+	}
+	now := time.Now()
+	side, running := g.clock.Running()
+	for i, p := range []game.Player{game.PBlack, game.PWhite} {
+		left, periods, period := g.clock.Remaining(p, now)
+		label := game.FormatClock(left)
+		low := left < 10*time.Second
+		if left == 0 && g.clock.TC.Periods > 0 {
+			label = fmt.Sprintf("%dx %s", periods, game.FormatClock(period))
+			low = periods <= 1 && period < 10*time.Second
+		}
+		if running && p == side {
+			label += " <"
+		}
+		col := color.Color(color.White)
+		if low {
+			col = lowTimeColor
+		}
+		drawTextWithShadow(screen, label, 120, 30+i*20, color.Black, col)
+	}
+	drawTextWithShadow(screen, g.clock.TC.Kind()+" "+g.clock.TC.String(), 20, 90, color.Black, color.White)
+}
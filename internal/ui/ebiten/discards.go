@@ -0,0 +1,105 @@
+// File internal/ui/ebiten/discards.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+const (
+	discardPanelW = 210
+	discardRowH   = 20
+)
+
+// fadeFrom 用被清理掉的连通块构造淡出动画；没有弃子时返回 nil
+func fadeFrom(removed []game.RemovedComponent) *fadeAnim {
+	if len(removed) == 0 {
+		return nil
+	}
+	f := &fadeAnim{}
+	for _, comp := range removed {
+		for c, st := range comp {
+			st := st
+			f.board.Cells[c.X][c.Y] = &st
+			f.cells = append(f.cells, c)
+		}
+	}
+	return f
+}
+
+// advanceFades 推进淡出动画并丢弃已结束的
+func advanceFades(fades []*fadeAnim) []*fadeAnim {
+	var next []*fadeAnim
+	for _, f := range fades {
+		f.frame++
+		if !f.done() {
+			next = append(next, f)
+		}
+	}
+	return next
+}
+
+// captures 一步跳子为行动方吃掉的对方棋子数；红子与本方棋子不计
+func captures(mover game.Player, removed []game.RemovedComponent) int {
+	n := 0
+	for _, comp := range removed {
+		for _, st := range comp {
+			for _, p := range st {
+				if p == opponent(mover).Piece() {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// capturedIn 重放 moves 的前 n 步，统计双方吃子数，按 Player 下标
+func capturedIn(states []game.GameState, moves []game.Move, n int) [2]int {
+	var captured [2]int
+	for i := 0; i < n && i < len(moves); i++ {
+		jm, ok := moves[i].(game.JumpMove)
+		if !ok {
+			continue
+		}
+		gs := states[i]
+		gs.Board = states[i].Board.Clone()
+		removed, err := game.PlayReport(&gs, jm)
+		if err != nil {
+			continue
+		}
+		captured[jm.Player] += captures(jm.Player, removed)
+	}
+	return captured
+}
+
+// drawDiscards 右上角的弃子面板：弃子堆中各色棋子数与双方吃子数
+func drawDiscards(screen *ebiten.Image, b *game.Board, captured [2]int) {
+	var red, white, black int
+	for _, p := range b.Discard {
+		switch p {
+		case game.Red:
+			red++
+		case game.White:
+			white++
+		case game.Black:
+			black++
+		}
+	}
+
+	x := screenW - discardPanelW - 20
+	vector.DrawFilledRect(screen, float32(x-8), 12, discardPanelW+16, 5*discardRowH+10, menuPanelColor, false)
+	lines := []string{
+		fmt.Sprintf("Discarded: %d", len(b.Discard)),
+		fmt.Sprintf("  Red %d  White %d  Black %d", red, white, black),
+		"Captured:",
+		fmt.Sprintf("  White %d", captured[game.PWhite]),
+		fmt.Sprintf("  Black %d", captured[game.PBlack]),
+	}
+	for i, l := range lines {
+		drawTextWithShadow(screen, l, x, 30+i*discardRowH, color.Black, color.White)
+	}
+}
@@ -0,0 +1,214 @@
+// File internal/ui/ebiten/editor.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"image/color"
+	"os"
+	"strings"
+)
+
+/*
+局面编辑器：摆出任意局面用于研究或复现问题。
+  - 单击选中格子；R / W / B 在堆顶加一枚棋子，Shift+R / W / B 加在堆底
+  - Backspace / Delete 或右键移除堆顶，X 清空该格，[ / ] 把堆顶移到堆底 / 堆底移到堆顶
+  - Tab 切换行棋方，P 切换摆子 / 跳子阶段，C 清空棋盘
+  - 局面随改随校验（见 game.SetupPosition）；合规时回车开局、A 开局并打开分析
+  - E 把局面串写入 PositionFile，I 从中读回；Esc 回到菜单
+*/
+
+// PositionFile 编辑器导出 / 导入局面串的文件
+const PositionFile = "dvonn-position.txt"
+
+const editorHelp = "Click: select  R/W/B: add on top (Shift: at bottom)  Del: remove top  X: clear cell  [ ]: reorder  " +
+	"Tab: side  P: phase  C: clear  Enter: play  A: analyse  E/I: export/import  Esc: menu"
+
+type editorView struct {
+	app *App
+
+	board game.Board
+	phase game.GamePhase
+	side  game.Player
+
+	at       game.Coordinate // 选中的格子
+	selected bool
+
+	gs  game.GameState // 按当前设置构造的局面
+	err error          // 校验结果；nil 表示可以开局
+	msg string
+}
+
+// showEditor 打开局面编辑器；有进行中的对局时从其局面开始，否则从空棋盘开始
+func (a *App) showEditor() {
+	e := &editorView{app: a, board: game.EmptyDvonn.Clone(), phase: game.Phase2, side: game.PBlack}
+	if a.game != nil {
+		a.game.pauseClock()
+		e.board = a.game.state.Board.Clone()
+		e.phase, e.side = a.game.state.Phase, game.ToMove(&a.game.state)
+	}
+	e.validate()
+	a.scene = e
+}
+
+func (e *editorView) validate() {
+	e.gs, e.err = game.SetupPosition(e.board, e.phase, e.side)
+}
+
+func (e *editorView) Update() error {
+	handleFullscreen()
+	handleBoardView()
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		e.at, e.selected = pixelToCoord(ebiten.CursorPosition())
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		if c, ok := pixelToCoord(ebiten.CursorPosition()); ok {
+			e.at, e.selected = c, true
+			e.edit(game.RemoveTop)
+		}
+	}
+
+	if e.selected {
+		bottom := ebiten.IsKeyPressed(ebiten.KeyShift)
+		for key, p := range map[ebiten.Key]game.Piece{ebiten.KeyR: game.Red, ebiten.KeyW: game.White, ebiten.KeyB: game.Black} {
+			if inpututil.IsKeyJustPressed(key) {
+				e.edit(func(st game.Stack) game.Stack {
+					if bottom {
+						return game.AddBottom(st, p)
+					}
+					return game.AddTop(st, p)
+				})
+			}
+		}
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyBackspace), inpututil.IsKeyJustPressed(ebiten.KeyDelete):
+			e.edit(game.RemoveTop)
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			e.edit(func(game.Stack) game.Stack { return nil })
+		case inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft):
+			e.edit(game.TopToBottom)
+		case inpututil.IsKeyJustPressed(ebiten.KeyBracketRight):
+			e.edit(game.BottomToTop)
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		e.side = opponent(e.side)
+		e.validate()
+	case inpututil.IsKeyJustPressed(ebiten.KeyP):
+		e.phase = 1 - e.phase
+		e.validate()
+	case inpututil.IsKeyJustPressed(ebiten.KeyC):
+		e.board, e.selected = game.EmptyDvonn.Clone(), false
+		e.validate()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		e.start(false)
+	case inpututil.IsKeyJustPressed(ebiten.KeyA):
+		e.start(true)
+	case inpututil.IsKeyJustPressed(ebiten.KeyE):
+		e.export()
+	case inpututil.IsKeyJustPressed(ebiten.KeyI):
+		e.load()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		e.app.showMenu()
+	}
+	return nil
+}
+
+// edit 对选中格的堆做一次修改
+func (e *editorView) edit(fn func(game.Stack) game.Stack) {
+	e.board.EditStack(e.at, fn)
+	e.msg = ""
+	e.validate()
+}
+
+// start 从当前局面开局；analyse 时以人人对战打开并开启分析
+func (e *editorView) start(analyse bool) {
+	if e.err != nil {
+		e.msg = "Fix the position first"
+		return
+	}
+	mode := e.app.settings.Mode
+	if analyse {
+		mode = "pvp"
+	}
+	e.app.startGame(e.gs, mode, nil, nil)
+	if analyse {
+		e.app.game.toggleAnalysis()
+	}
+}
+
+// export 把局面串写入 PositionFile，可用于 bug 报告
+func (e *editorView) export() {
+	pos := game.FormatPosition(&e.gs)
+	if err := os.WriteFile(PositionFile, []byte(pos+"\n"), 0o644); err != nil {
+		e.msg = "Export failed: " + err.Error()
+		return
+	}
+	e.msg = "Position written to " + PositionFile
+}
+
+// load 从 PositionFile 读回局面串
+func (e *editorView) load() {
+	data, err := os.ReadFile(PositionFile)
+	if err == nil {
+		var gs game.GameState
+		if gs, err = game.ParsePosition(strings.TrimSpace(string(data))); err == nil {
+			e.board, e.phase, e.side = gs.Board.Clone(), gs.Phase, game.ToMove(&gs)
+			e.selected = false
+			e.validate()
+			e.msg = "Position read from " + PositionFile
+			return
+		}
+	}
+	e.msg = "Import failed: " + err.Error()
+}
+
+func (e *editorView) Draw(screen *ebiten.Image) {
+	screen.DrawImage(boardBG, nil)
+	forEachCoordinate(func(c game.Coordinate) {
+		drawStack(&e.board, c, screen)
+	})
+	if e.selected {
+		drawCircleColored(screen, e.at, highlightBlue)
+		drawStack(&e.board, e.at, screen)
+	}
+
+	phase := "movement"
+	if e.phase == game.Phase1 {
+		phase = "placement"
+	}
+	var counts [3]int
+	forEachCoordinate(func(c game.Coordinate) {
+		for _, p := range stackAt(&e.board, c) {
+			counts[p]++
+		}
+	})
+	lines := []string{
+		"Board editor",
+		fmt.Sprintf("Phase: %s   To move: %v", phase, e.side),
+		fmt.Sprintf("On board: Red %d  White %d  Black %d", counts[game.Red], counts[game.White], counts[game.Black]),
+	}
+	if e.selected {
+		lines = append(lines, fmt.Sprintf("%s: %s", game.FormatCoord(e.at), stackAt(&e.board, e.at)))
+	}
+	for i, l := range lines {
+		drawTextWithShadow(screen, l, 20, 30+i*20, color.Black, color.White)
+	}
+
+	y := 30 + len(lines)*20 + 10
+	if e.err == nil {
+		drawTextWithShadow(screen, "Position OK", 20, y, color.Black, highlightGreen)
+	} else {
+		for i, l := range strings.Split(e.err.Error(), "\n") {
+			drawTextWithShadow(screen, l, 20, y+i*20, color.Black, lowTimeColor)
+		}
+	}
+
+	drawTextWithShadow(screen, e.msg, 20, statusY-20, color.Black, color.White)
+	drawTextWithShadow(screen, editorHelp, 20, statusY, color.Black, color.White)
+}
@@ -0,0 +1,221 @@
+// File internal/ui/ebiten/gameover.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"sort"
+	"strings"
+)
+
+const (
+	overlayW    = 640
+	overlayRowH = 22
+	buttonW     = 110
+	buttonH     = 28
+	buttonGap   = 16
+)
+
+var (
+	overlayColor     = color.RGBA{0x20, 0x20, 0x20, 0xD0}
+	buttonColor      = color.RGBA{0x44, 0x44, 0x44, 0xFF}
+	whiteStackColor  = color.RGBA{0xF0, 0xF0, 0xF0, 0xFF}
+	blackStackColor  = color.RGBA{0x20, 0x20, 0x20, 0xFF}
+	overlayTextColor = color.White
+)
+
+// stackScore 一个计入终局得分的堆
+type stackScore struct {
+	at     game.Coordinate
+	height int
+}
+
+// gameResult 终局统计：各色控制的堆（按高度降序）与弃子数
+type gameResult struct {
+	winner       *game.Player
+	white, black int
+	whiteStacks  []stackScore
+	blackStacks  []stackScore
+	discarded    int
+	onTime       bool // 超时判负
+}
+
+// newGameResult 统计终局；flagged 非 nil 时该方超时判负，与盘面比分无关
+func newGameResult(gs *game.GameState, flagged *game.Player) *gameResult {
+	r := &gameResult{winner: game.Winner(gs), discarded: len(gs.Board.Discard)}
+	if flagged != nil {
+		w := opponent(*flagged)
+		r.winner, r.onTime = &w, true
+	}
+	forEachCoordinate(func(c game.Coordinate) {
+		st := gs.Board.Cells[c.X][c.Y]
+		if st == nil || len(*st) == 0 {
+			return
+		}
+		switch (*st)[0] {
+		case game.White:
+			r.white += len(*st)
+			r.whiteStacks = append(r.whiteStacks, stackScore{c, len(*st)})
+		case game.Black:
+			r.black += len(*st)
+			r.blackStacks = append(r.blackStacks, stackScore{c, len(*st)})
+		}
+	})
+	for _, s := range [][]stackScore{r.whiteStacks, r.blackStacks} {
+		sort.SliceStable(s, func(i, j int) bool { return s[i].height > s[j].height })
+	}
+	return r
+}
+
+func (r *gameResult) headline() string {
+	if r.winner == nil {
+		return fmt.Sprintf("Draw  %d - %d", r.white, r.black)
+	}
+	if r.onTime {
+		return fmt.Sprintf("%v wins on time!  White %d - Black %d", *r.winner, r.white, r.black)
+	}
+	return fmt.Sprintf("%v wins!  White %d - Black %d", *r.winner, r.white, r.black)
+}
+
+// stackLine 如 "White 23 in 4 stacks: C3 9, E4 7, ..."
+func stackLine(p game.Player, total int, stacks []stackScore) string {
+	parts := make([]string, len(stacks))
+	for i, s := range stacks {
+		parts[i] = fmt.Sprintf("%s %d", game.FormatCoord(s.at), s.height)
+	}
+	return fmt.Sprintf("%-5v %2d in %d stacks: %s", p, total, len(stacks), strings.Join(parts, ", "))
+}
+
+// overlayButton 终局面板上的按钮
+type overlayButton struct {
+	label  string
+	key    ebiten.Key
+	action func(g *GameView)
+}
+
+// overlayButtons 联网对局不支持再来一局
+func (g *GameView) overlayButtons() []overlayButton {
+	var bs []overlayButton
+	if g.net == nil {
+		bs = append(bs, overlayButton{"Rematch (N)", ebiten.KeyN, (*GameView).rematch})
+	}
+	return append(bs,
+		overlayButton{"Review (R)", ebiten.KeyR, (*GameView).startReview},
+		overlayButton{"Save (S)", ebiten.KeyS, (*GameView).save},
+		overlayButton{"Board (Tab)", ebiten.KeyTab, func(g *GameView) { g.hideResult = !g.hideResult }},
+	)
+}
+
+// overlayRect 面板左上角与高度；面板在画布中居中
+func (g *GameView) overlayRect() (x, y, h int) {
+	h = 5*overlayRowH + buttonH + 40
+	return (screenW - overlayW) / 2, (screenH - h) / 2, h
+}
+
+// buttonRect 第 i 个按钮的左上角
+func (g *GameView) buttonRect(i, n int) (int, int) {
+	x, y, h := g.overlayRect()
+	total := n*buttonW + (n-1)*buttonGap
+	return x + (overlayW-total)/2 + i*(buttonW+buttonGap), y + h - buttonH - 16
+}
+
+// updateResult 终局后处理面板按钮：快捷键或单击；面板隐藏时只响应快捷键
+func (g *GameView) updateResult() {
+	bs := g.overlayButtons()
+	click := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !g.hideResult
+	mx, my := ebiten.CursorPosition()
+	for i, b := range bs {
+		bx, by := g.buttonRect(i, len(bs))
+		hit := click && mx >= bx && mx < bx+buttonW && my >= by && my < by+buttonH
+		if hit || inpututil.IsKeyJustPressed(b.key) {
+			b.action(g)
+			return
+		}
+	}
+}
+
+// drawResult 绘制终局面板；隐藏时只在底部留一行比分
+func (g *GameView) drawResult(screen *ebiten.Image) {
+	r := g.result
+	x, y, h := g.overlayRect()
+	if g.hideResult {
+		drawTextWithShadow(screen, r.headline()+"  -  Tab: show result", 20, statusY, color.Black, color.White)
+		return
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), overlayW, float32(h), overlayColor, false)
+
+	lines := []string{
+		r.headline(),
+		stackLine(game.PWhite, r.white, r.whiteStacks),
+		stackLine(game.PBlack, r.black, r.blackStacks),
+		fmt.Sprintf("Discarded: %d pieces", r.discarded),
+		g.message,
+	}
+	for i, l := range lines {
+		drawTextWithShadow(screen, l, x+20, y+30+i*overlayRowH, color.Black, overlayTextColor)
+	}
+
+	bs := g.overlayButtons()
+	for i, b := range bs {
+		bx, by := g.buttonRect(i, len(bs))
+		vector.DrawFilledRect(screen, float32(bx), float32(by), buttonW, buttonH, buttonColor, false)
+		vector.StrokeRect(screen, float32(bx), float32(by), buttonW, buttonH, 1, highlightBlue, false)
+		drawTextWithShadow(screen, b.label, bx+(buttonW-7*len(b.label))/2, by+buttonH/2+4, color.Black, overlayTextColor)
+	}
+}
+
+// drawCountedStacks 终局时用所属颜色的圆环圈出计分的堆
+func (g *GameView) drawCountedStacks(screen *ebiten.Image) {
+	ring := func(stacks []stackScore, col color.Color) {
+		for _, s := range stacks {
+			x, y := coordToScreen(s.at)
+			vector.StrokeCircle(screen, float32(x), float32(y), float32(triangleR*0.55), float32(triangleR)/20+1, col, true)
+		}
+	}
+	ring(g.result.whiteStacks, whiteStackColor)
+	ring(g.result.blackStacks, blackStackColor)
+}
+
+// rematch 以相同模式重开一局；人机对战时交换双方执子
+func (g *GameView) rematch() {
+	if g.mode == "pve" {
+		g.aiPlayer = opponent(g.aiPlayer)
+	}
+	g.analyzer.Stop()
+	g.mover.Stop()
+	if g.review != nil {
+		g.review.close()
+	}
+	resetInput()
+	gs := NewGameState(g.mode, g.autoPlace)
+	g.state = gs
+	g.record = game.NewRecord(&gs, g.mode)
+	g.anims, g.fades, g.pendingMv, g.hint = nil, nil, nil, nil
+	g.preview, g.review, g.clockStamp, g.aiAnimPlaying = nil, nil, nil, false
+	g.captured = [2]int{}
+	if g.clock != nil {
+		g.SetTimeControl(g.clock.TC)
+	}
+	g.showedResult, g.hideResult, g.result = false, false, nil
+	g.analysisKey, g.message = 0, ""
+}
+
+// save 保存对局记录，可用 -replay 回放
+func (g *GameView) save() {
+	if err := g.record.Save(recordFile); err != nil {
+		g.message = "Save failed: " + err.Error()
+	} else {
+		g.message = "Game saved to " + recordFile
+	}
+}
+
+func opponent(p game.Player) game.Player {
+	if p == game.PWhite {
+		return game.PBlack
+	}
+	return game.PWhite
+}
@@ -4,25 +4,57 @@ package ebiten
 import (
 	"dvonn_go/internal/ai"
 	"dvonn_go/internal/game"
+	"dvonn_go/internal/netplay"
 	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font/basicfont"
 	"image/color"
+	"time"
 )
 
+// depth 为 AI 的默认搜索深度，菜单中可调
 const depth = 4
 
+// recordFile 为按 S 保存对局记录的文件名
+const recordFile = "dvonn-game.json"
+
 type GameView struct {
 	state        game.GameState
 	mode         string
 	aiPlayer     game.Player
+	aiDepth      int
 	anims        []*Animation
+	fades        []*fadeAnim    // 弃子淡出
+	captured     [2]int         // 双方吃掉的对方棋子数，按 Player 下标
 	pendingMv    *game.JumpMove //�ȴ�ִ�е�����
 	showedResult bool
+	result       *gameResult // 终局统计，showedResult 后有效
+	hideResult   bool        // Tab 收起终局面板以查看棋盘
+	message      string      // 最近一次保存的结果
+	autoPlace    bool        // 再来一局时是否自动摆子
+
+	clock      *game.Clock      // 非 nil 时为计时对局
+	clockStamp *game.ClockStamp // 最近一次停钟的戳记，执行着法时写入记录
 
 	// ��������ǡ���ǰ���׶����Ƿ�����AI���������ڶ������Ž����󴥷�ʡ��
 	aiAnimPlaying bool
+
+	// 分析模式：后台引擎持续评估当前局面
+	analysis    bool
+	analyzer    *ai.Analyzer
+	analysisKey uint64 // 上次启动分析时的局面键
+
+	mover ai.Mover // 人机对战：AI 的后台搜索，不阻塞 Update
+
+	record  *game.Record   // 本局记录
+	hint    *game.JumpMove // 当前显示的提示着法
+	preview *movePreview   // 悬停在合法终点上时的跳子预演
+	review  *reviewMode    // 非 nil 时处于复盘模式
+
+	net *netplay.Conn // 非 nil 时为联网对局
+
+	back func() // 非 nil 时 Esc 返回菜单
 }
 
 func NewGameView(gs game.GameState, mode string) *GameView {
@@ -31,35 +63,107 @@ func NewGameView(gs game.GameState, mode string) *GameView {
 		state:         gs,
 		mode:          mode,
 		aiPlayer:      game.PWhite,
+		aiDepth:       depth,
 		anims:         []*Animation{},
 		pendingMv:     nil,
 		showedResult:  false,
+		autoPlace:     gs.PlaceStep > 0,
 		aiAnimPlaying: false,
+		analyzer:      ai.NewAnalyzer(analysisDepth, analysisMultiPV),
+		record:        game.NewRecord(&gs, mode),
+	}
+}
+
+// play 执行一步并写入记录；执行后旧提示作废
+func (g *GameView) play(mv game.Move) {
+	before := g.state
+	before.Board = g.state.Board.Clone()
+	removed, err := game.PlayReport(&g.state, mv)
+	if err != nil {
+		return
+	}
+	if jm, ok := mv.(game.JumpMove); ok {
+		g.captured[jm.Player] += captures(jm.Player, removed)
+		if f := fadeFrom(removed); f != nil {
+			g.fades = append(g.fades, f)
+		}
 	}
+	g.record.Add(mv)
+	if g.clockStamp != nil {
+		g.record.Stamp(*g.clockStamp)
+		g.clockStamp = nil
+	}
+	g.hint = nil
+	narrateMove(&before, mv, &g.state)
 }
 
 func (g *GameView) Update() error {
+	handleFullscreen()
+
+	// 复盘模式接管输入
+	if g.review != nil {
+		if g.review.update() {
+			g.review.close()
+			g.review = nil
+		}
+		return nil
+	}
+
+	// Esc 回到菜单，对局保留可继续；有选择或正在输入记谱时 Esc 先取消它们
+	if g.back != nil && !typing && !selected && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.back()
+		return nil
+	}
+
+	// 0) A 键开关分析模式
+	if !typing && inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.toggleAnalysis()
+	}
+	// L 键开关坐标标注，F 键翻转棋盘
+	if !typing {
+		handleBoardView()
+	}
+	// H 键为当前行棋方给出提示
+	if !typing && inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		g.requestHint()
+	}
+
+	// 0.1) 摆子阶段的“随机摆完”
+	g.updatePlacement()
+
 	// 1) ������� �� ���� Move
-	if mv := handleInput(&g.state); mv != nil {
+	if mv := g.input(); mv != nil {
+		g.pressClock()
 		switch m := mv.(type) {
 		case game.PlaceMove:
 			// ���ӽ׶Σ�����ִ��
-			game.RunPlacementPhase(&g.state, m.At.X, m.At.Y)
+			g.play(m)
 
 		case game.JumpMove:
 			// ���ӽ׶Σ�**��** ����ִ�У�ֻ���붯�� & ������Ҷ����������� aiAnimPlaying��
 			mv2 := m
 			g.pendingMv = &mv2
+			// 拖放落子时堆已在终点，动画只保留一帧
+			frames := 0
+			if dropped {
+				frames, dropped = 1, false
+			}
 			g.anims = append(g.anims, &Animation{
-				Piece: m.Player.Piece(),
-				From:  m.From,
-				To:    m.To,
+				Piece:  m.Player.Piece(),
+				From:   m.From,
+				To:     m.To,
+				Frames: frames,
 			})
 		}
 	}
 
+	// 1.1) 联网：取出对手着法
+	g.pollRemote()
+	g.updatePreview()
+	g.tickClock()
+
 	// 2) PvE AI ���ӣ�ֻ���𣬲����̺ϲ�
-	if !game.IsGameOver(&g.state) &&
+	if !g.over() &&
 		g.mode == "pve" &&
 		g.state.Phase == game.Phase2 &&
 		game.TurnStateToPlayer(g.state.Turn) == g.aiPlayer &&
@@ -67,7 +171,20 @@ func (g *GameView) Update() error {
 		len(g.anims) == 0 &&
 		g.pendingMv == nil {
 
-		best := ai.SearchBestMove(&g.state, depth)
+		// 在后台搜索，窗口照常刷新、走钟；计时对局中按 AI 自己的棋钟分配搜索时间
+		if !g.mover.Thinking() {
+			var budget time.Duration
+			if g.clock != nil {
+				budget = ai.AllocateTime(g.clock, g.aiPlayer, time.Now())
+			}
+			g.mover.Start(g.state, g.aiDepth, budget)
+		}
+	}
+	if g.over() {
+		g.mover.Stop() // 思考期间超时判负
+	}
+	if best, ok := g.mover.Result(); ok {
+		g.pressClock()
 		mv2 := best
 		g.pendingMv = &mv2
 		g.anims = append(g.anims, &Animation{
@@ -83,11 +200,12 @@ func (g *GameView) Update() error {
 	for _, a := range g.anims {
 		a.frame++
 	}
+	g.fades = advanceFades(g.fades)
 
 	// 4) ������׶�����ɣ�������ִ��һ�� RunMovementPhase
 	if len(g.anims) > 0 && g.anims[0].done() && g.pendingMv != nil {
 		m := *g.pendingMv
-		game.RunMovementPhase(&g.state, m.From, m.To)
+		g.play(m)
 		g.pendingMv = nil
 	}
 
@@ -113,50 +231,57 @@ func (g *GameView) Update() error {
 	}
 
 	//6) ��Ϸ����
-	if game.IsGameOver(&g.state) && !g.showedResult {
-		// 1) ͳ�Ƹ��Կ��Ƶ�����
-		var whiteCnt, blackCnt int
-		forEachCoordinate(func(c game.Coordinate) {
-			st := g.state.Board.Cells[c.X][c.Y]
-			if st == nil || len(*st) == 0 {
-				return
-			}
-			switch (*st)[0] {
-			case game.White:
-				whiteCnt += len(*st)
-			case game.Black:
-				blackCnt += len(*st)
-			}
-		})
-
-		// 2) ���ͳ�ƽ��
-		fmt.Printf("Game over! White controls %d pieces; Black controls %d pieces.", whiteCnt, blackCnt)
-
-		// 3) ���ʤ��
-		switch {
-		case whiteCnt > blackCnt:
-			fmt.Println(" White wins!")
-		case blackCnt > whiteCnt:
-			fmt.Println(" Black wins!")
-		default:
-			fmt.Println(" It's a draw!")
+	if g.over() && !g.showedResult {
+		var flagged *game.Player
+		if g.clock != nil {
+			flagged = g.clock.Flagged
+		}
+		g.result = newGameResult(&g.state, flagged)
+		g.record.Result = g.result.headline()
+		if flagged != nil {
+			narrate("%s", g.result.headline())
 		}
-
 		g.showedResult = true
 	}
 
+	// 终局面板：再来一局、复盘、保存；对局中 S 键同样保存记录
+	switch {
+	case typing:
+		// 记谱输入框打开时不响应快捷键
+	case g.showedResult:
+		g.updateResult()
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		g.save()
+	}
+
+	if g.analysis {
+		g.refreshAnalysis()
+	}
+
 	booted = true
 	// ?? ȥ��ԭ��ÿ֡���������� leavePerf() �Ĵ���
 	return nil
 }
 
 func (g *GameView) Draw(screen *ebiten.Image) {
+	if g.review != nil {
+		g.review.draw(screen)
+		return
+	}
+
 	// 1. �������̱���
 	screen.DrawImage(boardBG, nil)
 
 	// 1.1 ���Ͻ���ʾ˫����ǰ�ɿ�������
 	blackScore, whiteScore := currentScores(&g.state.Board)
 	drawScoreboard(screen, blackScore, whiteScore)
+	drawHintCounter(screen, g.record)
+	g.drawClocks(screen)
+	if g.state.Phase == game.Phase1 {
+		g.drawPlacement(screen)
+	} else {
+		drawDiscards(screen, &g.state.Board, g.captured)
+	}
 
 	// 2. Phase2 ��δѡ��ʱ���������ƶ���
 	if g.state.Phase == game.Phase2 && !selected {
@@ -173,27 +298,80 @@ func (g *GameView) Draw(screen *ebiten.Image) {
 		}
 	}
 
+	// 3.1 提示着法
+	if g.hint != nil {
+		drawHint(screen, *g.hint)
+	}
+
 	// 4. ���� �����ء� �б� ����  �ڶ��������ڼ䣬����Դ���Ŀ���ľ�̬����
 	hide := map[game.Coordinate]bool{}
 	for _, a := range g.anims {
 		hide[a.From] = true
 	}
+	if d.active {
+		hide[d.from] = true
+	}
 
 	// 5. �������о�̬���ӣ��������ڶ�����Դ/Ŀ���
 	forEachCoordinate(func(c game.Coordinate) {
 		if hide[c] {
 			return
 		}
+		if g.previewGhosted(c) {
+			drawStackAlpha(&g.state.Board, c, screen, 0.35)
+			return
+		}
 		drawStack(&g.state.Board, c, screen)
 	})
+	g.drawPreview(screen)
 
 	// 6. ���Ŷ���֡
 	for _, a := range g.anims {
 		a.Draw(screen)
 	}
+	for _, f := range g.fades {
+		f.Draw(screen)
+	}
+
+	// 6.1 拖拽中的堆跟随光标
+	if d.active {
+		if st := g.state.Board.Cells[d.from.X][d.from.Y]; st != nil {
+			x, y := dragPosition()
+			drawStackAt(*st, x, y, screen, 0.85)
+		}
+	}
+
+	// 7. 分析模式叠加层
+	if g.analysis {
+		g.drawAnalysis(screen)
+	}
+
+	g.drawNetStatus(screen)
+	if g.localTurn() {
+		drawKeyboard(screen)
+	}
+
+	if g.showedResult {
+		g.drawCountedStacks(screen)
+		g.drawResult(screen)
+	}
 }
-func (g *GameView) Layout(_, _ int) (int, int) {
-	return 1300, 768
+
+// input 读取本地输入；联网时不是本方回合或对手拒收则丢弃
+func (g *GameView) input() game.Move {
+	if !g.localTurn() || g.over() {
+		return nil
+	}
+	mv := handleInput(&g.state)
+	if mv == nil || !g.sendLocal(mv) {
+		dropped = false
+		return nil
+	}
+	return mv
+}
+
+func (g *GameView) Layout(outsideW, outsideH int) (int, int) {
+	return layoutScreen(outsideW, outsideH)
 }
 
 // currentScores ͳ�ƺڰ�˫����ǰ���Ƶ���������
@@ -230,6 +408,6 @@ func drawScoreboard(screen *ebiten.Image, blackScore, whiteScore int) {
 }
 
 func drawTextWithShadow(screen *ebiten.Image, label string, x, y int, shadowColor, textColor color.Color) {
-	text.Draw(screen, label, basicfont.Face7x13, x+1, y+1, shadowColor)
-	text.Draw(screen, label, basicfont.Face7x13, x, y, textColor)
+	text.Draw(screen, label, uiFace, x+1, y+1, shadowColor)
+	text.Draw(screen, label, uiFace, x, y, textColor)
 }
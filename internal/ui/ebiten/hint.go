@@ -0,0 +1,40 @@
+// File internal/ui/ebiten/hint.go
+package ebiten
+
+import (
+	"dvonn_go/internal/ai"
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+)
+
+// 提示只做浅层搜索，保证按键后立即有结果
+const hintDepth = 2
+
+// requestHint 为当前行棋方（本地人类）搜索一步提示并计入记录；轮到 AI 或联网对手时不给提示
+func (g *GameView) requestHint() {
+	if g.state.Phase != game.Phase2 || game.IsGameOver(&g.state) || g.pendingMv != nil || !g.localTurn() {
+		return
+	}
+	pl := game.TurnStateToPlayer(g.state.Turn)
+
+	best := ai.SearchBestMove(&g.state, hintDepth)
+	if best == (game.JumpMove{}) {
+		return
+	}
+	g.hint = &best
+	g.record.AddHint(pl)
+}
+
+// drawHint 用已有的高亮色标出提示的起点与落点
+func drawHint(screen *ebiten.Image, mv game.JumpMove) {
+	drawCircleColored(screen, mv.From, highlightBlue)
+	drawCircleColored(screen, mv.To, highlightGreen)
+}
+
+// drawHintCounter 在记分牌下方显示双方已用提示数
+func drawHintCounter(screen *ebiten.Image, r *game.Record) {
+	label := fmt.Sprintf("Hints: B %d / W %d", r.Hints[game.PBlack], r.Hints[game.PWhite])
+	drawTextWithShadow(screen, label, 20, 70, color.Black, color.White)
+}
@@ -8,13 +8,20 @@ import (
 	"math"
 )
 
+// dragState tracks a stack being dragged: it is picked up on press and
+// dropped on release. Releasing over the origin counts as a plain click.
 type dragState struct {
 	active bool
 	from   game.Coordinate
+	x0, y0 int // cursor position at press, so the stack does not jump to the cursor
 }
 
 var d dragState
 
+// dropped reports that the last jump was made by drag-and-drop; the stack is
+// already at its destination, so no flight animation is needed.
+var dropped bool
+
 var (
 	// clickStep = 0: nothing selected
 	// clickStep = 1: waiting for destination
@@ -28,10 +35,20 @@ var (
 	selectedAt game.Coordinate
 )
 
-// pixelToCoord converts screen pixels to a board coordinate.
+// resetInput clears the half-finished selection, e.g. when a new game starts.
+func resetInput() {
+	clickStep = 0
+	selected = false
+	d = dragState{}
+	dropped = false
+}
+
+// pixelToCoord converts screen pixels to a board coordinate, undoing the
+// 180° rotation first when the board is flipped.
 func pixelToCoord(x, y int) (game.Coordinate, bool) {
-	relX := float64(x) - offsetX
-	relY := float64(y) - offsetY
+	px, py := orient(float64(x), float64(y))
+	relX := px - offsetX
+	relY := py - offsetY
 
 	r := relY / (triangleR * 3.0 / 2)
 	q := (relX / (triangleR * math.Sqrt(3))) - (r / 2)
@@ -52,8 +69,15 @@ func pixelToCoord(x, y int) (game.Coordinate, bool) {
 	return game.Coordinate{}, false
 }
 
-// handleInput returns a user move (if any) based on mouse clicks.
+// handleInput returns a user move (if any) based on the keyboard, mouse clicks
+// or drag-and-drop.
 func handleInput(gs *game.GameState) game.Move {
+	if d.active {
+		return handleDrop(gs)
+	}
+	if mv := handleKeyboard(gs); mv != nil {
+		return mv
+	}
 	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		return nil
 	}
@@ -65,10 +89,23 @@ func handleInput(gs *game.GameState) game.Move {
 		selected = false
 		return nil
 	}
+	kbCursor = c
 
+	idle := clickStep == 0
+	mv := selectCell(gs, c)
+	if idle && clickStep == 1 {
+		// a freshly picked-up stack can also be dragged to its destination
+		d = dragState{active: true, from: c, x0: x, y0: y}
+	}
+	return mv
+}
+
+// selectCell acts on a cell chosen by click or keyboard: it places a piece in
+// phase 1; in phase 2 the first choice selects a movable stack and the second
+// either names a legal destination or cancels the selection.
+func selectCell(gs *game.GameState, c game.Coordinate) game.Move {
 	if gs.Phase == game.Phase1 {
-		game.RunPlacementPhase(gs, c.X, c.Y)
-		return nil
+		return game.PlaceMove{Piece: game.NextPiece(gs), At: c}
 	}
 
 	if gs.Phase == game.Phase2 {
@@ -100,3 +137,39 @@ func handleInput(gs *game.GameState) game.Move {
 
 	return nil
 }
+
+// handleDrop finishes a drag once the button is released: a legal destination
+// yields the jump, the origin keeps the selection for a second click, and any
+// other cell snaps the stack back.
+func handleDrop(gs *game.GameState) game.Move {
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		return nil
+	}
+	d.active = false
+
+	c, ok := pixelToCoord(ebiten.CursorPosition())
+	if ok && c == d.from {
+		return nil
+	}
+	clickStep = 0
+	selected = false
+	if !ok {
+		return nil
+	}
+	for _, dst := range destinations(gs, d.from) {
+		if dst == c {
+			enterPerf()
+			dropped = true
+			return game.JumpMove{Player: game.TurnStateToPlayer(gs.Turn), From: d.from, To: c}
+		}
+	}
+	return nil
+}
+
+// dragPosition returns where the dragged stack is drawn: its cell centre
+// moved by however far the cursor has travelled since the press.
+func dragPosition() (float64, float64) {
+	cx, cy := coordToScreen(d.from)
+	x, y := ebiten.CursorPosition()
+	return cx + float64(x-d.x0), cy + float64(y-d.y0)
+}
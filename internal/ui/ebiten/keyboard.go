@@ -0,0 +1,142 @@
+// File internal/ui/ebiten/keyboard.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"strings"
+	"unicode"
+)
+
+/*
+纯键盘操作：
+  - 光标沿六个方向移动：以 H 为中心的 Y U / G J / B N 一圈，
+    方向键 ←/→ 同 G/J，↑ 同 U（右上），↓ 同 B（左下）
+  - 回车或空格选择光标处的格子（与单击相同），Esc / Backspace 取消选择
+  - "/" 打开记谱输入框，输入 "C3" 或 "C3-E3" 后回车，Esc 关闭
+*/
+
+// hexKeys 六个方向对应的按键与 Coordinate 增量（X = q+5，Y = r+2）
+var hexKeys = []struct {
+	keys  []ebiten.Key
+	delta game.Coordinate
+}{
+	{[]ebiten.Key{ebiten.KeyY}, game.Coordinate{X: 0, Y: -1}},                 // 左上
+	{[]ebiten.Key{ebiten.KeyU, ebiten.KeyUp}, game.Coordinate{X: 1, Y: -1}},   // 右上
+	{[]ebiten.Key{ebiten.KeyG, ebiten.KeyLeft}, game.Coordinate{X: -1, Y: 0}}, // 左
+	{[]ebiten.Key{ebiten.KeyJ, ebiten.KeyRight}, game.Coordinate{X: 1, Y: 0}}, // 右
+	{[]ebiten.Key{ebiten.KeyB, ebiten.KeyDown}, game.Coordinate{X: -1, Y: 1}}, // 左下
+	{[]ebiten.Key{ebiten.KeyN}, game.Coordinate{X: 0, Y: 1}},                  // 右下
+}
+
+var (
+	kbCursor = game.Coordinate{X: 5, Y: 2} // 键盘光标，初始在棋盘中心
+	kbShown  bool                          // 用过键盘后才显示光标
+
+	typing   bool   // 记谱输入框打开中，独占键盘
+	typed    []rune // 已输入的记谱
+	typedErr string // 上次提交失败的原因
+
+	mouseX, mouseY int // 上一帧的鼠标位置；鼠标移动时隐藏键盘光标
+)
+
+var kbCursorColor = color.RGBA{0xFF, 0xD7, 0x00, 0xFF}
+
+// handleKeyboard 处理光标移动、选择与记谱输入，返回完成的着法
+func handleKeyboard(gs *game.GameState) game.Move {
+	if typing {
+		return handleTyping(gs)
+	}
+	if x, y := ebiten.CursorPosition(); x != mouseX || y != mouseY {
+		mouseX, mouseY = x, y
+		kbShown = false
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySlash) {
+		typing, typed, typedErr = true, nil, ""
+		return nil
+	}
+
+	for _, hk := range hexKeys {
+		for _, k := range hk.keys {
+			if !inpututil.IsKeyJustPressed(k) {
+				continue
+			}
+			// 棋盘翻转时方向随之翻转，按键始终对应屏幕上的方向
+			delta := hk.delta
+			if boardRotated {
+				delta = delta.Neg()
+			}
+			// 第一次按键只显示光标
+			if next := kbCursor.Add(delta); kbShown && onBoard(next) {
+				kbCursor = next
+			}
+			kbShown = true
+			narrateCell(gs, kbCursor)
+			return nil
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		kbShown = true
+		return selectCell(gs, kbCursor)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape), inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		clickStep = 0
+		selected = false
+	}
+	return nil
+}
+
+// handleTyping 记谱输入框：回车提交，合法时返回着法，否则保留输入并提示原因
+func handleTyping(gs *game.GameState) game.Move {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == ' ' {
+			typed = append(typed, unicode.ToUpper(r))
+		}
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(typed) > 0:
+		typed = typed[:len(typed)-1]
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		typing = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		mv, err := game.ParseMove(string(typed), gs)
+		if err != nil {
+			typedErr = "cannot read " + strings.TrimSpace(string(typed))
+			return nil
+		}
+		// 在副本上试走一步判断是否合法
+		trial := *gs
+		trial.Board = gs.Board.Clone()
+		if err := game.Play(&trial, mv); err != nil {
+			typedErr = game.FormatMove(mv) + " is not legal here"
+			return nil
+		}
+		typing, typed = false, nil
+		clickStep = 0
+		selected = false
+		if jm, ok := mv.(game.JumpMove); ok {
+			kbCursor = jm.To
+		}
+		return mv
+	}
+	return nil
+}
+
+// drawKeyboard 画键盘光标与记谱输入框
+func drawKeyboard(screen *ebiten.Image) {
+	if kbShown {
+		x, y := coordToScreen(kbCursor)
+		vector.StrokeCircle(screen, float32(x), float32(y), float32(triangleR*0.5), 3, kbCursorColor, true)
+	}
+	if typing {
+		label := "Move: " + string(typed) + "_   (Enter: play  Esc: close)"
+		if typedErr != "" {
+			label += "   " + typedErr
+		}
+		drawTextWithShadow(screen, label, 20, statusY-20, color.Black, color.White)
+	}
+}
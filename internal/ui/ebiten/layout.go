@@ -0,0 +1,112 @@
+// File internal/ui/ebiten/layout.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"math"
+)
+
+/*
+与分辨率无关的布局：画布大小 = 窗口大小 × 设备缩放因子，按物理像素绘制，
+4K 屏上不会被拉伸模糊。棋盘按画布大小缩放并居中，上下各留出文字区；
+文字区的位置贴着画布上沿 / 下沿，不随棋盘缩放。
+画布尺寸变化时重算格半径与偏移，并重画 boardBG。
+*/
+
+const (
+	baseW = 1300 // 布局的参考尺寸，与原固定画布一致
+	baseH = 768
+
+	uiTop    = 60  // 上方文字区：比分、评估条
+	uiBottom = 120 // 下方文字区：分析变例、时间轴、状态行
+)
+
+// 随画布尺寸变化的布局量；由 layoutScreen 维护，各视图只读
+var (
+	screenW, screenH int
+
+	triangleR        float64 // 格半径
+	triangleH        float64
+	offsetX, offsetY float64 // 轴向坐标 (0,0) 的屏幕位置
+	layerOffsetY     float64 // 每层棋子的垂直偏移
+
+	pvTextY   int     // 分析变例首行
+	timelineY int     // 回放时间轴
+	timelineW int     //
+	statusY   int     // 最底部的提示行
+	evalBarX  float32 // 评估条水平居中
+)
+
+// 格半径为 1 时棋盘格心的外包范围
+var boardMinX, boardMaxX, boardMinY, boardMaxY = boardExtent()
+
+func boardExtent() (minX, maxX, minY, maxY float64) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	forEachCoordinate(func(c game.Coordinate) {
+		q, r := axialFromIndex(c)
+		x, y := math.Sqrt(3)*q+math.Sqrt(3)/2*r, 1.5*r
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	})
+	return
+}
+
+// layoutScreen 供各视图的 Layout 调用：返回物理像素的画布大小，尺寸变化时重排
+func layoutScreen(outsideW, outsideH int) (int, int) {
+	s := ebiten.Monitor().DeviceScaleFactor()
+	w, h := int(math.Ceil(float64(outsideW)*s)), int(math.Ceil(float64(outsideH)*s))
+	if w <= 0 || h <= 0 {
+		w, h = baseW, baseH
+	}
+	if w != screenW || h != screenH {
+		relayout(w, h)
+	}
+	return w, h
+}
+
+// relayout 按画布 w×h 重算棋盘几何与文字区位置，并重画棋盘背景
+func relayout(w, h int) {
+	screenW, screenH = w, h
+
+	// 左右各留一格半放棋子与行号，上方留两格给高堆与字母，下方留一格
+	spanX := boardMaxX - boardMinX + 3
+	spanY := boardMaxY - boardMinY + 3
+	availH := float64(h - uiTop - uiBottom)
+	triangleR = math.Max(8, math.Min(float64(w)/spanX, availH/spanY))
+	triangleH = math.Sqrt(3) * triangleR / 2
+	layerOffsetY = triangleR * layerOffsetRatio
+
+	offsetX = float64(w)/2 - (boardMinX+boardMaxX)/2*triangleR
+	offsetY = uiTop + availH/2 - (boardMinY+boardMaxY)/2*triangleR + triangleR/2
+	boardCX = offsetX + (boardMinX+boardMaxX)/2*triangleR
+	boardCY = offsetY + (boardMinY+boardMaxY)/2*triangleR
+
+	pvTextY = h - 108
+	timelineY = h - 53
+	timelineW = w - 2*timelineX
+	statusY = h - 18
+	evalBarX = float32(w-evalBarW) / 2
+
+	if boardBG != nil {
+		boardBG.Deallocate()
+	}
+	boardBG = ebiten.NewImage(w, h)
+	boardBG.Fill(backgroundColor)
+	forEachCoordinate(func(c game.Coordinate) {
+		drawCircle(boardBG, c)
+	})
+	drawGridLines(boardBG)
+	if showLabels {
+		drawEdgeLabels(boardBG)
+	}
+}
+
+// handleFullscreen F11 切换全屏；各视图在 Update 中调用
+func handleFullscreen() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		ebiten.SetFullscreen(!ebiten.IsFullscreen())
+	}
+}
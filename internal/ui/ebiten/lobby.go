@@ -0,0 +1,160 @@
+// File internal/ui/ebiten/lobby.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"dvonn_go/internal/netplay"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+const (
+	lobbyX     = 40
+	lobbyY     = 120 // 第一行的基线
+	lobbyRowH  = 26
+	lobbyRowW  = 900
+	lobbyTitle = "LAN games - Up/Down or click to select, Enter to join, W to watch"
+)
+
+// joinResult 后台加入的结果
+type joinResult struct {
+	conn *netplay.Conn
+	err  error
+}
+
+// LobbyView 列出局域网上公告的对局，选中后加入；加入成功后转交给 GameView
+type LobbyView struct {
+	browser *netplay.Browser
+	games   []netplay.Announcement
+	cursor  int
+
+	joining chan joinResult // 非 nil 时正在连接
+	msg     string          // 最近一次加入失败的原因
+
+	game *GameView // 加入后的对局
+}
+
+// NewLobbyView 用 browser 收集的公告创建大厅
+func NewLobbyView(browser *netplay.Browser) *LobbyView {
+	return &LobbyView{browser: browser}
+}
+
+func (l *LobbyView) Update() error {
+	if l.game != nil {
+		return l.game.Update()
+	}
+	handleFullscreen()
+	if l.joining != nil {
+		select {
+		case res := <-l.joining:
+			l.joining = nil
+			if res.err != nil {
+				l.msg = "Join failed: " + res.err.Error()
+				return nil
+			}
+			l.browser.Close()
+			if res.conn.Spectator() {
+				l.game = NewNetGameView(game.StartState(), "watch", res.conn)
+				ebiten.SetWindowTitle("DVONN – spectator")
+			} else {
+				l.game = NewNetGameView(game.StartState(), "join", res.conn)
+				ebiten.SetWindowTitle("DVONN – " + res.conn.Seat().String() + " (join)")
+			}
+		default:
+		}
+		return nil
+	}
+
+	l.games = l.browser.Games()
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		l.cursor++
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		l.cursor--
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		l.join(false)
+	case inpututil.IsKeyJustPressed(ebiten.KeyW):
+		l.join(true)
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		_, y := ebiten.CursorPosition()
+		if i := (y - lobbyY + lobbyRowH*3/4) / lobbyRowH; y >= lobbyY-lobbyRowH*3/4 && i < len(l.games) {
+			if i == l.cursor {
+				l.join(false) // 再次点击已选中的一行即加入
+			}
+			l.cursor = i
+		}
+	}
+	l.cursor = max(0, min(l.cursor, len(l.games)-1))
+	return nil
+}
+
+// join 在后台连接选中的对局；watch 或对局已无空位时以观众身份连接
+func (l *LobbyView) join(watch bool) {
+	if l.cursor >= len(l.games) {
+		return
+	}
+	ann := l.games[l.cursor]
+	if ann.Kind != netplay.KindHost {
+		l.msg = ann.Addr + " is a game server; use its HTTP API to play"
+		return
+	}
+	connect := netplay.Join
+	if watch || ann.Seat == "" {
+		connect = netplay.Watch
+	}
+	l.msg = ""
+	l.joining = make(chan joinResult, 1)
+	go func(ch chan<- joinResult) {
+		conn, err := connect(ann.Addr)
+		ch <- joinResult{conn, err}
+	}(l.joining)
+}
+
+func (l *LobbyView) Draw(screen *ebiten.Image) {
+	if l.game != nil {
+		l.game.Draw(screen)
+		return
+	}
+	screen.DrawImage(boardBG, nil)
+	drawTextWithShadow(screen, lobbyTitle, lobbyX, 60, color.Black, color.White)
+
+	if len(l.games) == 0 {
+		drawTextWithShadow(screen, "Searching for games on the local network...", lobbyX, lobbyY, color.Black, color.White)
+	}
+	for i, ann := range l.games {
+		y := lobbyY + i*lobbyRowH
+		if i == l.cursor {
+			vector.DrawFilledRect(screen, lobbyX-6, float32(y-lobbyRowH*3/4), lobbyRowW, lobbyRowH, highlightBlue, false)
+		}
+		drawTextWithShadow(screen, describeGame(ann), lobbyX, y, color.Black, color.White)
+	}
+
+	status := l.msg
+	if l.joining != nil {
+		status = "Connecting..."
+	}
+	drawTextWithShadow(screen, status, lobbyX, statusY, color.Black, color.White)
+}
+
+func (l *LobbyView) Layout(outsideW, outsideH int) (int, int) {
+	return layoutScreen(outsideW, outsideH)
+}
+
+// describeGame 大厅中一行的文字
+func describeGame(ann netplay.Announcement) string {
+	if ann.Kind == netplay.KindServer {
+		return fmt.Sprintf("%-24s %-28s server, %d game(s)", ann.Name, ann.Addr, ann.Games)
+	}
+	watchers := ""
+	if ann.Watchers > 0 {
+		watchers = fmt.Sprintf(", %d watching", ann.Watchers)
+	}
+	if ann.Seat == "" {
+		return fmt.Sprintf("%-24s %-28s in progress%s", ann.Name, ann.Addr, watchers)
+	}
+	return fmt.Sprintf("%-24s %-28s you play %s%s", ann.Name, ann.Addr, ann.Seat, watchers)
+}
@@ -0,0 +1,309 @@
+// File internal/ui/ebiten/menu.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"strings"
+)
+
+const (
+	menuW    = 360
+	menuY    = 180 // 第一项的基线
+	menuRowH = 30
+	menuHelp = "Up/Down: select  Left/Right: change  Enter or click: choose  Esc: back  F11: fullscreen"
+)
+
+// scene 是 App 中的一个界面；GameView、ReplayView、LobbyView 与菜单页都满足
+type scene interface {
+	Update() error
+	Draw(screen *ebiten.Image)
+}
+
+// App 在主菜单、设置页与对局之间切换的小状态机，本身实现 ebiten.Game
+type App struct {
+	settings Settings
+	scene    scene
+	game     *GameView // 进行中的对局，菜单中可继续
+	msg      string    // 菜单底部的提示，如保存结果
+	quit     bool
+}
+
+// NewApp 以主菜单启动
+func NewApp(s Settings) *App {
+	a := &App{settings: s}
+	a.msg = strings.Join(s.apply(), "; ")
+	a.showMenu()
+	return a
+}
+
+func (a *App) Update() error {
+	if a.quit {
+		return ebiten.Termination
+	}
+	return a.scene.Update()
+}
+
+func (a *App) Draw(screen *ebiten.Image) {
+	a.scene.Draw(screen)
+}
+
+func (a *App) Layout(outsideW, outsideH int) (int, int) {
+	return layoutScreen(outsideW, outsideH)
+}
+
+// showMenu 回到主菜单；选项随当前设置与是否有进行中的对局变化
+func (a *App) showMenu() {
+	s := &a.settings
+	if a.game != nil {
+		a.game.pauseClock()
+	}
+	var items []menuItem
+	if a.game != nil {
+		items = append(items,
+			menuItem{label: "Resume game", action: func(int) { a.scene = a.game }},
+			menuItem{label: "Save game (" + recordFile + ")", action: func(int) { a.saveGame() }},
+		)
+	}
+	items = append(items,
+		menuItem{label: "New game", action: func(int) { a.newGame() }},
+		menuItem{label: "Mode", value: func() string { return strings.ToUpper(s.Mode) },
+			action: func(delta int) { s.Mode = cycle([]string{"pve", "pvp"}, s.Mode, delta); a.saveSettings() }},
+		menuItem{label: "Your side (PvE)", value: func() string { return s.Side },
+			action: func(delta int) { s.Side = cycle([]string{"black", "white"}, s.Side, delta); a.saveSettings() }},
+		menuItem{label: "AI level", value: func() string { return fmt.Sprint(s.AILevel) },
+			action: func(delta int) { s.AILevel = cycleInt(minAILevel, maxAILevel, s.AILevel, delta); a.saveSettings() }},
+		menuItem{label: "Clock", value: func() string { return s.Clock },
+			action: func(delta int) { s.Clock = cycle(TimeControls, s.Clock, delta); a.saveSettings() }},
+		menuItem{label: "Auto-placement (PvP)", value: func() string { return onOff(s.AutoPlace) },
+			action: func(int) { s.AutoPlace = !s.AutoPlace; a.saveSettings() }},
+		menuItem{label: "Load game (" + recordFile + ")", action: func(int) { a.loadGame() }},
+		menuItem{label: "Board editor", action: func(int) { a.showEditor() }},
+		menuItem{label: "Settings", action: func(int) { a.showSettings() }},
+		menuItem{label: "Quit", action: func(int) { a.quit = true }},
+	)
+	var back func()
+	if a.game != nil {
+		back = func() { a.scene = a.game }
+	}
+	a.scene = &menuScene{app: a, title: "DVONN", items: items, back: back}
+}
+
+// showSettings 设置页：界面相关的选项，改动立即生效并保存
+func (a *App) showSettings() {
+	s := &a.settings
+	speeds := make([]string, len(animSpeeds))
+	for i, sp := range animSpeeds {
+		speeds[i] = sp.name
+	}
+	items := []menuItem{
+		{label: "Fullscreen", value: func() string { return onOff(s.Fullscreen) },
+			action: func(int) { s.Fullscreen = !s.Fullscreen; a.saveSettings() }},
+		{label: "Animation speed", value: func() string { return s.AnimSpeed },
+			action: func(delta int) { s.AnimSpeed = cycle(speeds, s.AnimSpeed, delta); a.saveSettings() }},
+		{label: "Coordinate labels", value: func() string { return onOff(s.Labels) },
+			action: func(int) { s.Labels = !s.Labels; a.saveSettings() }},
+		{label: "Rotate board 180", value: func() string { return onOff(s.Rotated) },
+			action: func(int) { s.Rotated = !s.Rotated; a.saveSettings() }},
+		{label: "Theme", value: func() string { return s.Theme },
+			action: func(delta int) { s.Theme = cycle(ThemeNames(), s.Theme, delta); a.saveSettings() }},
+		{label: "Colours", value: func() string { return s.Palette },
+			action: func(delta int) { s.Palette = cycle(PaletteNames(), s.Palette, delta); a.saveSettings() }},
+		{label: "Move narration", value: func() string { return s.Narration },
+			action: func(delta int) {
+				s.Narration = cycle([]string{"off", "stdout", "log"}, s.Narration, delta)
+				a.saveSettings()
+			}},
+		{label: "Back", action: func(int) { a.showMenu() }},
+	}
+	a.scene = &menuScene{app: a, title: "Settings", items: items, back: a.showMenu}
+}
+
+// newGame 按当前设置开一局新棋，替换进行中的对局
+func (a *App) newGame() {
+	a.startGame(NewGameState(a.settings.Mode, a.settings.AutoPlace), a.settings.Mode, nil, nil)
+}
+
+// loadGame 读取保存的记录，从记录末尾的局面继续对局
+func (a *App) loadGame() {
+	rec, err := game.LoadRecord(recordFile)
+	if err != nil {
+		a.msg = "Load failed: " + err.Error()
+		return
+	}
+	states, moves, err := rec.Replay()
+	if err != nil {
+		a.msg = "Load failed: " + err.Error()
+		return
+	}
+	mode := rec.Mode
+	if mode != "pve" && mode != "pvp" {
+		mode = a.settings.Mode
+	}
+	a.startGame(states[len(states)-1], mode, rec, states)
+	a.game.captured = capturedIn(states, moves, len(moves))
+}
+
+// startGame 创建对局视图并切换过去；rec 非 nil 时沿用已有记录，states 为其重放结果
+func (a *App) startGame(gs game.GameState, mode string, rec *game.Record, states []game.GameState) {
+	if a.game != nil {
+		a.game.analyzer.Stop()
+		a.game.mover.Stop()
+		if a.game.review != nil {
+			a.game.review.close()
+		}
+	}
+	resetInput()
+	g := NewGameView(gs, mode)
+	if rec != nil {
+		g.record = rec
+	}
+	g.aiPlayer = a.settings.aiPlayer()
+	g.aiDepth = a.settings.AILevel
+	g.autoPlace = a.settings.AutoPlace
+	g.back = a.showMenu
+	if rec != nil {
+		// 读取的对局沿用记录中的用时规则与剩余时间
+		clock, _ := rec.ResumeClock(states)
+		g.clock = clock
+	} else {
+		tc, _ := game.ParseTimeControl(a.settings.Clock)
+		g.SetTimeControl(tc)
+	}
+	a.game, a.msg = g, ""
+	a.scene = g
+}
+
+func (a *App) saveGame() {
+	if err := a.game.record.Save(recordFile); err != nil {
+		a.msg = "Save failed: " + err.Error()
+		return
+	}
+	a.msg = "Game saved to " + recordFile
+}
+
+// saveSettings 应用并保存设置；应用或保存失败只提示，不影响本次使用
+func (a *App) saveSettings() {
+	a.msg = strings.Join(a.settings.apply(), "; ")
+	if err := a.settings.Save(SettingsFile); err != nil {
+		a.msg = "Settings not saved: " + err.Error()
+	}
+}
+
+// menuItem 菜单中的一行；value 非 nil 时为可切换的选项，显示在标签右侧
+type menuItem struct {
+	label  string
+	value  func() string
+	action func(delta int) // delta 为 0 表示回车 / 单击，±1 为左右键
+}
+
+// menuScene 一页竖排菜单
+type menuScene struct {
+	app    *App
+	title  string
+	items  []menuItem
+	cursor int
+	back   func() // Esc；nil 时忽略
+}
+
+func (m *menuScene) Update() error {
+	handleFullscreen()
+	// 全屏、标注与翻转可能由 F11 / L / F 切换，与设置保持一致
+	m.app.settings.Fullscreen = ebiten.IsFullscreen()
+	m.app.settings.Labels, m.app.settings.Rotated = showLabels, boardRotated
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		m.cursor = (m.cursor + 1) % len(m.items)
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		m.cursor = (m.cursor - 1 + len(m.items)) % len(m.items)
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		m.activate(-1, true)
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		m.activate(1, true)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		m.activate(0, false)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		if m.back != nil {
+			m.back()
+		}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		left := menuLeft()
+		if i := (y - menuY + menuRowH*3/4) / menuRowH; x >= left && x <= left+menuW && y >= menuY-menuRowH*3/4 && i < len(m.items) {
+			m.cursor = i
+			m.activate(0, false)
+		}
+	}
+	return nil
+}
+
+// activate 触发当前项；左右键只作用于可切换的选项
+func (m *menuScene) activate(delta int, optionsOnly bool) {
+	it := m.items[m.cursor]
+	if optionsOnly && it.value == nil {
+		return
+	}
+	it.action(delta)
+}
+
+func (m *menuScene) Draw(screen *ebiten.Image) {
+	screen.DrawImage(boardBG, nil)
+	left := menuLeft()
+	vector.DrawFilledRect(screen, float32(left-20), menuY-70, menuW+40, float32(len(m.items)*menuRowH+90), menuPanelColor, false)
+	drawTextWithShadow(screen, m.title, left, menuY-40, color.Black, color.White)
+
+	for i, it := range m.items {
+		y := menuY + i*menuRowH
+		if i == m.cursor {
+			vector.DrawFilledRect(screen, float32(left-6), float32(y-menuRowH*3/4), menuW+12, menuRowH, highlightBlue, false)
+		}
+		drawTextWithShadow(screen, it.label, left, y, color.Black, color.White)
+		if it.value != nil {
+			v := "< " + it.value() + " >"
+			drawTextWithShadow(screen, v, left+menuW-7*len(v), y, color.Black, color.White)
+		}
+	}
+
+	drawTextWithShadow(screen, m.app.msg, 20, statusY-20, color.Black, color.White)
+	drawTextWithShadow(screen, menuHelp, 20, statusY, color.Black, color.White)
+}
+
+var menuPanelColor = color.RGBA{0x20, 0x20, 0x20, 0xC0}
+
+// menuLeft 菜单水平居中
+func menuLeft() int {
+	return (screenW - menuW) / 2
+}
+
+// cycle 返回 opts 中 cur 之后第 delta 个选项（循环）；delta 为 0（回车 / 单击）时取下一个
+func cycle(opts []string, cur string, delta int) string {
+	i := 0
+	for j, o := range opts {
+		if o == cur {
+			i = j
+		}
+	}
+	return opts[cycleInt(0, len(opts)-1, i, delta)]
+}
+
+// cycleInt 在 [lo, hi] 内循环移动 delta 步；delta 为 0 时前进一步
+func cycleInt(lo, hi, cur, delta int) int {
+	if delta == 0 {
+		delta = 1
+	}
+	n := hi - lo + 1
+	return lo + ((cur-lo+delta)%n+n)%n
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,99 @@
+// File internal/ui/ebiten/netplay.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"dvonn_go/internal/netplay"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+)
+
+const (
+	netStatusY    = 90 // 连接状态文字的纵坐标
+	catchUpFrames = 3  // 观众落后时每步跳子动画的帧数
+)
+
+// NewNetGameView 创建联网对局视图；本地只操作 conn.Seat() 一方，观众连接则只读
+func NewNetGameView(gs game.GameState, mode string, conn *netplay.Conn) *GameView {
+	g := NewGameView(gs, mode)
+	g.net = conn
+	return g
+}
+
+// spectating 是否为只读观战
+func (g *GameView) spectating() bool {
+	return g.net != nil && g.net.Spectator()
+}
+
+// localTurn 是否允许本地输入：人机对战与联网时只在轮到本方时接受，观战时从不接受
+func (g *GameView) localTurn() bool {
+	if g.mode == "pve" && g.state.Phase == game.Phase2 && game.ToMove(&g.state) == g.aiPlayer {
+		return false
+	}
+	return g.net == nil || (!g.spectating() && game.ToMove(&g.state) == g.net.Seat())
+}
+
+// sendLocal 把本地着法交给对手；联网校验失败时返回 false，着法作废
+func (g *GameView) sendLocal(mv game.Move) bool {
+	if g.net == nil {
+		return true
+	}
+	return g.net.Send(mv) == nil
+}
+
+// pollRemote 取出远端着法：摆子直接执行（积压时一帧执行完），跳子走动画流程（同 AI）。
+// 观众或重连后落后多步时用短动画快速追上。
+func (g *GameView) pollRemote() {
+	if g.net == nil || len(g.anims) > 0 || g.pendingMv != nil {
+		return
+	}
+	for {
+		mv, ok := g.net.Poll()
+		if !ok {
+			return
+		}
+		switch m := mv.(type) {
+		case game.PlaceMove:
+			g.play(m)
+			continue
+		case game.JumpMove:
+			anim := &Animation{
+				Piece: m.Player.Piece(),
+				From:  m.From,
+				To:    m.To,
+			}
+			if g.net.Pending() > 0 {
+				anim.Frames = catchUpFrames
+			}
+			enterPerf()
+			g.pendingMv = &m
+			g.anims = append(g.anims, anim)
+			g.aiAnimPlaying = true
+		}
+		return
+	}
+}
+
+// drawNetStatus 显示连接状态与本方执子
+func (g *GameView) drawNetStatus(screen *ebiten.Image) {
+	if g.net == nil {
+		return
+	}
+	label := g.net.Status()
+	if n := g.net.Spectators(); n > 0 {
+		label += fmt.Sprintf(" (%d watching)", n)
+	}
+	switch {
+	case game.IsGameOver(&g.state):
+	case g.spectating():
+		label += " - " + game.ToMove(&g.state).String() + " to move"
+	default:
+		if g.localTurn() {
+			label += " - your turn"
+		} else {
+			label += " - opponent's turn"
+		}
+	}
+	drawTextWithShadow(screen, label, 20, netStatusY, color.Black, color.White)
+}
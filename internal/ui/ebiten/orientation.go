@@ -0,0 +1,118 @@
+// File internal/ui/ebiten/orientation.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+/*
+棋盘朝向与坐标标注：
+  - 边缘标出标准记谱（见 game.FormatCoord）：每行两端标行号 1–5，
+    每条斜线上下两端标字母 A–K
+  - 棋盘可旋转 180°，让执子一方从自己这一侧看棋；旋转在 coordToScreen 中完成，
+    pixelToCoord 先把点击位置转回未旋转的棋盘
+*/
+
+var (
+	showLabels   = true // 边缘坐标标注
+	boardRotated bool   // 棋盘旋转 180°
+
+	boardCX, boardCY float64 // 旋转中心：棋盘格心外包框的中心，由 relayout 维护
+)
+
+// labelDistance 标注与边缘格心的距离，以相邻格心距为单位
+const labelDistance = 0.55
+
+// SetLabels 显示或隐藏边缘坐标标注
+func SetLabels(on bool) {
+	if on == showLabels {
+		return
+	}
+	showLabels = on
+	if screenW > 0 {
+		relayout(screenW, screenH)
+	}
+}
+
+// SetRotated 设置棋盘是否旋转 180°
+func SetRotated(on bool) {
+	if on == boardRotated {
+		return
+	}
+	boardRotated = on
+	if screenW > 0 {
+		relayout(screenW, screenH)
+	}
+}
+
+// handleBoardView L 键开关坐标标注，F 键翻转棋盘；对局与回放视图在 Update 中调用
+func handleBoardView() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		SetLabels(!showLabels)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		SetRotated(!boardRotated)
+	}
+}
+
+// orient 棋盘旋转时把屏幕坐标绕中心转 180°；自身即逆变换
+func orient(x, y float64) (float64, float64) {
+	if !boardRotated {
+		return x, y
+	}
+	return 2*boardCX - x, 2*boardCY - y
+}
+
+// drawEdgeLabels 在棋盘四周标出行号与斜线字母
+func drawEdgeLabels(dst *ebiten.Image) {
+	rowEnds := map[int][2]game.Coordinate{}   // 行号 -> 该行最左、最右的格子
+	diagEnds := map[byte][2]game.Coordinate{} // 字母 -> 该斜线最上、最下的格子
+	forEachCoordinate(func(c game.Coordinate) {
+		e, ok := rowEnds[c.Y]
+		if !ok {
+			e = [2]game.Coordinate{c, c}
+		}
+		if c.X < e[0].X {
+			e[0] = c
+		}
+		if c.X > e[1].X {
+			e[1] = c
+		}
+		rowEnds[c.Y] = e
+
+		col := game.FormatCoord(c)[0]
+		e, ok = diagEnds[col]
+		if !ok {
+			e = [2]game.Coordinate{c, c}
+		}
+		if c.Y < e[0].Y {
+			e[0] = c
+		}
+		if c.Y > e[1].Y {
+			e[1] = c
+		}
+		diagEnds[col] = e
+	})
+
+	for _, e := range rowEnds {
+		row := game.FormatCoord(e[0])[1:]
+		drawLabelBeside(dst, row, e[0], game.Coordinate{X: -1, Y: 0})
+		drawLabelBeside(dst, row, e[1], game.Coordinate{X: 1, Y: 0})
+	}
+	for col, e := range diagEnds {
+		drawLabelBeside(dst, string(col), e[0], game.Coordinate{X: 1, Y: -1})
+		drawLabelBeside(dst, string(col), e[1], game.Coordinate{X: -1, Y: 1})
+	}
+}
+
+// drawLabelBeside 把 label 居中画在格子 c 朝 dir 方向、离格心 labelDistance 格处
+func drawLabelBeside(dst *ebiten.Image, label string, c, dir game.Coordinate) {
+	x0, y0 := coordToScreen(c)
+	x1, y1 := coordToScreen(c.Add(dir))
+	x := x0 + (x1-x0)*labelDistance
+	y := y0 + (y1-y0)*labelDistance
+	text.Draw(dst, label, uiFace, int(x)-7*len(label)/2, int(y)+5, lineColor)
+}
@@ -0,0 +1,108 @@
+// File internal/ui/ebiten/placement.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+const randomiseLabel = "Randomise rest (R)"
+
+// placementButton “随机摆完”按钮的左上角；位于摆子面板底部
+func placementButton() (int, int) {
+	return screenW - discardPanelW - 20, 30 + 3*discardRowH
+}
+
+// canRandomise 联网对局中双方各摆各的，不提供随机摆完
+func (g *GameView) canRandomise() bool {
+	return g.state.Phase == game.Phase1 && g.net == nil
+}
+
+// updatePlacement 摆子阶段：R 键或单击按钮随机摆完剩余棋子
+func (g *GameView) updatePlacement() {
+	if !g.canRandomise() || typing {
+		return
+	}
+	click := false
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		bx, by := placementButton()
+		click = mx >= bx && mx < bx+discardPanelW && my >= by && my < by+buttonH
+	}
+	if click || inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.randomiseRest()
+	}
+}
+
+// randomiseRest 用 FillPhase1Auto 在副本上摆完，再按摆子顺序逐步执行，
+// 使记录与播报和手动摆子一致
+func (g *GameView) randomiseRest() {
+	g.pressClock()
+	filled := g.state
+	filled.Board = g.state.Board.Clone()
+	filled = game.FillPhase1Auto(&filled)
+
+	// 同色棋子不可区分，按顺序为每一步取一个放着该色棋子的新格子即可
+	var fresh []game.Coordinate
+	game.ForEachPlayable(func(c game.Coordinate) {
+		if len(stackAt(&g.state.Board, c)) == 0 && len(stackAt(&filled.Board, c)) > 0 {
+			fresh = append(fresh, c)
+		}
+	})
+	for g.state.Phase == game.Phase1 {
+		piece := game.NextPiece(&g.state)
+		i := 0
+		for i < len(fresh) && stackAt(&filled.Board, fresh[i])[0] != piece {
+			i++
+		}
+		if i == len(fresh) {
+			return
+		}
+		g.play(game.PlaceMove{Piece: piece, At: fresh[i]})
+		fresh = append(fresh[:i], fresh[i+1:]...)
+	}
+	resetInput()
+}
+
+// drawPlacement 摆子阶段：高亮空格，右上角显示轮到谁、下一枚棋子与剩余子数
+func (g *GameView) drawPlacement(screen *ebiten.Image) {
+	if g.localTurn() {
+		game.ForEachPlayable(func(c game.Coordinate) {
+			if len(stackAt(&g.state.Board, c)) == 0 {
+				drawCircleColored(screen, c, highlightGreen)
+			}
+		})
+	}
+
+	x := screenW - discardPanelW - 20
+	h := 3*discardRowH + 10
+	if g.canRandomise() {
+		h += buttonH + 8
+	}
+	vector.DrawFilledRect(screen, float32(x-8), 12, discardPanelW+16, float32(h), menuPanelColor, false)
+
+	piece := game.NextPiece(&g.state)
+	red, white, black := game.RemainingPieces(&g.state)
+	lines := []string{
+		fmt.Sprintf("%v to place:", game.PlacingPlayer(&g.state)),
+		fmt.Sprintf("  %s (piece %d of 49)", pieceName(piece), g.state.PlaceStep+1),
+		fmt.Sprintf("Left: Red %d  White %d  Black %d", red, white, black),
+	}
+	for i, l := range lines {
+		drawTextWithShadow(screen, l, x, 30+i*discardRowH, color.Black, color.White)
+	}
+	// 下一枚棋子的小图
+	size := float64(discardRowH) * 1.6
+	drawPieceIcon(screen, piece, float64(x+discardPanelW)-size/2, 30, size)
+
+	if g.canRandomise() {
+		bx, by := placementButton()
+		vector.DrawFilledRect(screen, float32(bx), float32(by), discardPanelW, buttonH, buttonColor, false)
+		vector.StrokeRect(screen, float32(bx), float32(by), discardPanelW, buttonH, 1, highlightBlue, false)
+		drawTextWithShadow(screen, randomiseLabel, bx+(discardPanelW-7*len(randomiseLabel))/2, by+buttonH/2+4, color.Black, overlayTextColor)
+	}
+}
@@ -0,0 +1,77 @@
+// File internal/ui/ebiten/preview.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+var discardColor = color.RGBA{0xE0, 0x30, 0x30, 0xFF}
+
+// movePreview 选中的堆悬停在合法终点上时，这步跳子的预演
+type movePreview struct {
+	from, to game.Coordinate
+	p        game.Preview
+}
+
+// updatePreview 跟随悬停的格子更新预演；起止点不变时沿用上次结果
+func (g *GameView) updatePreview() {
+	to, ok := hoveredCell()
+	if !selected || g.state.Phase != game.Phase2 || !g.localTurn() ||
+		!ok || !containsCoord(destinations(&g.state, selectedAt), to) {
+		g.preview = nil
+		return
+	}
+	if g.preview != nil && g.preview.from == selectedAt && g.preview.to == to {
+		return
+	}
+	mv := game.JumpMove{Player: game.ToMove(&g.state), From: selectedAt, To: to}
+	p, err := game.PreviewMove(&g.state, mv)
+	if err != nil {
+		g.preview = nil
+		return
+	}
+	g.preview = &movePreview{from: selectedAt, to: to, p: p}
+}
+
+// hoveredCell 键盘光标可见时取光标，否则取鼠标所在的格子
+func hoveredCell() (game.Coordinate, bool) {
+	if kbShown {
+		return kbCursor, true
+	}
+	return pixelToCoord(ebiten.CursorPosition())
+}
+
+// previewGhosted 该格的堆是否因预演中的跳子被移除，需半透明绘制
+func (g *GameView) previewGhosted(c game.Coordinate) bool {
+	if g.preview == nil {
+		return false
+	}
+	_, ok := g.preview.p.Discarded[c]
+	return ok
+}
+
+// drawPreview 圈出将被移除的堆，并在终点旁标注移除数与双方控制子数变化
+func (g *GameView) drawPreview(screen *ebiten.Image) {
+	pv := g.preview
+	if pv == nil {
+		return
+	}
+	for c := range pv.p.Discarded {
+		x, y := coordToScreen(c)
+		vector.StrokeCircle(screen, float32(x), float32(y), float32(triangleR*0.55), 3, discardColor, true)
+		r := float32(triangleR * 0.3)
+		vector.StrokeLine(screen, float32(x)-r, float32(y)-r, float32(x)+r, float32(y)+r, 3, discardColor, true)
+		vector.StrokeLine(screen, float32(x)-r, float32(y)+r, float32(x)+r, float32(y)-r, 3, discardColor, true)
+	}
+
+	label := fmt.Sprintf("White %+d  Black %+d", pv.p.Delta[game.PWhite], pv.p.Delta[game.PBlack])
+	if n := pv.p.Removed(); n > 0 {
+		label = fmt.Sprintf("%d removed  ", n) + label
+	}
+	x, y := coordToScreen(pv.to)
+	drawTextWithShadow(screen, label, int(x+triangleR*0.6), int(y+triangleR*0.7), color.Black, color.White)
+}
@@ -0,0 +1,217 @@
+// File internal/ui/ebiten/replay.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+	"time"
+)
+
+const (
+	fadeFrames = 15 // 弃子淡出的帧数
+
+	timelineX = 20 // timelineY、timelineW 随画布尺寸变化，见 layout.go
+	timelineH = 10
+
+	minAutoplay = 100 * time.Millisecond
+	maxAutoplay = 5 * time.Second
+)
+
+// fadeAnim 让一步跳子后被移除的连通块逐帧淡出
+type fadeAnim struct {
+	board game.Board // 只含被移除的堆，位于清理前的位置
+	cells []game.Coordinate
+	frame int
+}
+
+func (f *fadeAnim) done() bool { return f.frame >= fadeFrames }
+
+func (f *fadeAnim) Draw(screen *ebiten.Image) {
+	alpha := 1 - float32(f.frame)/fadeFrames
+	for _, c := range f.cells {
+		drawStackAlpha(&f.board, c, screen, alpha)
+	}
+}
+
+// ReplayView 回放一份对局记录：单步前进/后退、跳到任意一步、自动播放
+type ReplayView struct {
+	record *game.Record
+	states []game.GameState
+	moves  []game.Move
+	ply    int // 当前显示 states[ply]
+
+	anim *Animation // 正在播放的跳子（结束后 ply 才前进）
+	fade *fadeAnim
+
+	autoplay bool
+	interval time.Duration
+	lastStep time.Time
+}
+
+// NewReplayView 重放记录并创建回放界面；interval 为自动播放的每步间隔
+func NewReplayView(rec *game.Record, interval time.Duration) (*ReplayView, error) {
+	states, moves, err := rec.Replay()
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayView{
+		record:   rec,
+		states:   states,
+		moves:    moves,
+		interval: clampInterval(interval),
+	}, nil
+}
+
+func (v *ReplayView) Update() error {
+	handleFullscreen()
+	handleBoardView()
+
+	last := len(v.states) - 1
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		v.stepForward()
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		v.seek(v.ply - 1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyPageDown):
+		v.seek(v.ply + 10)
+	case inpututil.IsKeyJustPressed(ebiten.KeyPageUp):
+		v.seek(v.ply - 10)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		v.seek(0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		v.seek(last)
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		v.autoplay = !v.autoplay
+		v.lastStep = time.Now()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEqual), inpututil.IsKeyJustPressed(ebiten.KeyKPAdd):
+		v.interval = clampInterval(v.interval / 2)
+	case inpututil.IsKeyJustPressed(ebiten.KeyMinus), inpututil.IsKeyJustPressed(ebiten.KeyKPSubtract):
+		v.interval = clampInterval(v.interval * 2)
+	}
+
+	// 点击时间轴跳转
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		if x >= timelineX && x <= timelineX+timelineW && y >= timelineY-6 && y <= timelineY+timelineH+6 {
+			v.seek((x - timelineX) * last / timelineW)
+		}
+	}
+
+	// 自动播放
+	if v.autoplay && v.anim == nil && time.Since(v.lastStep) >= v.interval {
+		if v.ply >= last {
+			v.autoplay = false
+		} else {
+			v.stepForward()
+		}
+	}
+
+	// 推进动画：跳子结束后进入下一局面并淡出弃子
+	if v.anim != nil {
+		v.anim.frame++
+		if v.anim.done() {
+			v.anim = nil
+			v.fade = discardFade(&v.states[v.ply], v.moves[v.ply].(game.JumpMove))
+			v.ply++
+		}
+	}
+	if v.fade != nil {
+		v.fade.frame++
+		if v.fade.done() {
+			v.fade = nil
+		}
+	}
+
+	booted = true
+	return nil
+}
+
+// stepForward 前进一步；跳子先播放动画
+func (v *ReplayView) stepForward() {
+	if v.ply >= len(v.moves) || v.anim != nil {
+		return
+	}
+	v.lastStep = time.Now()
+	v.fade = nil
+	if jm, ok := v.moves[v.ply].(game.JumpMove); ok {
+		v.anim = &Animation{Piece: jm.Player.Piece(), From: jm.From, To: jm.To}
+		return
+	}
+	v.ply++
+}
+
+// seek 直接跳到第 ply 步之前的局面，取消进行中的动画
+func (v *ReplayView) seek(ply int) {
+	v.ply = max(0, min(ply, len(v.states)-1))
+	v.anim, v.fade = nil, nil
+	v.lastStep = time.Now()
+}
+
+// discardFade 在副本上重走 mv，取出被清理掉的连通块供淡出绘制
+func discardFade(before *game.GameState, mv game.JumpMove) *fadeAnim {
+	gs := *before
+	gs.Board = before.Board.Clone()
+	removed, err := game.PlayReport(&gs, mv)
+	if err != nil {
+		return nil
+	}
+	return fadeFrom(removed)
+}
+
+func (v *ReplayView) Draw(screen *ebiten.Image) {
+	screen.DrawImage(boardBG, nil)
+
+	gs := &v.states[v.ply]
+	forEachCoordinate(func(c game.Coordinate) {
+		if v.anim != nil && c == v.anim.From {
+			return
+		}
+		drawStack(&gs.Board, c, screen)
+	})
+	if v.anim != nil {
+		v.anim.Draw(screen)
+	}
+	if v.fade != nil {
+		v.fade.Draw(screen)
+	}
+
+	blackScore, whiteScore := currentScores(&gs.Board)
+	drawScoreboard(screen, blackScore, whiteScore)
+	drawDiscards(screen, &gs.Board, capturedIn(v.states, v.moves, v.ply))
+
+	// 当前着法（刚走完的一步）及评注
+	label := fmt.Sprintf("Move %d/%d", v.ply, len(v.moves))
+	if v.ply > 0 {
+		e := v.record.Moves[v.ply-1]
+		label += "  " + e.Move
+		if e.Annotation != "" {
+			label += "  [" + e.Annotation + "]"
+		}
+	}
+	drawTextWithShadow(screen, label, 20, 70, color.Black, color.White)
+
+	// 时间轴
+	last := max(len(v.states)-1, 1)
+	vector.DrawFilledRect(screen, timelineX, float32(timelineY), float32(timelineW), timelineH, lineColor, false)
+	vector.DrawFilledRect(screen, timelineX, float32(timelineY), float32(timelineW*v.ply/last), timelineH, highlightBlue, false)
+
+	play := "paused"
+	if v.autoplay {
+		play = "playing"
+	}
+	help := fmt.Sprintf("Left/Right: step  PgUp/PgDn: 10  Home/End  Space: autoplay (%s, %.1fs/move)  +/-: speed  click timeline: seek",
+		play, v.interval.Seconds())
+	drawTextWithShadow(screen, help, 20, statusY, color.Black, color.White)
+}
+
+func (v *ReplayView) Layout(outsideW, outsideH int) (int, int) {
+	return layoutScreen(outsideW, outsideH)
+}
+
+func clampInterval(d time.Duration) time.Duration {
+	return max(minAutoplay, min(d, maxAutoplay))
+}
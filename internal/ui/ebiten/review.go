@@ -0,0 +1,173 @@
+// File internal/ui/ebiten/review.go
+package ebiten
+
+import (
+	"dvonn_go/internal/game"
+	"dvonn_go/internal/review"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"image/color"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	reviewDepth = 2                   // 复盘每个局面的搜索深度
+	reviewFile  = "dvonn-review.json" // 导出带评注记录的文件名
+)
+
+var (
+	playedArrowColor = color.RGBA{0xFF, 0x8C, 0x00, 0xE0}
+	bestArrowColor   = color.RGBA{0x00, 0xCC, 0x66, 0xC0}
+	classColors      = map[review.Class]color.Color{
+		review.Best:       color.RGBA{0x00, 0xCC, 0x66, 0xFF},
+		review.Good:       color.RGBA{0xB0, 0xC4, 0xDE, 0xFF},
+		review.Inaccuracy: color.RGBA{0xFF, 0xD7, 0x00, 0xFF},
+		review.Blunder:    color.RGBA{0xFF, 0x40, 0x40, 0xFF},
+	}
+)
+
+// reviewMode 终局后的复盘：逐步回看整局，后台引擎为每步跳子评注
+type reviewMode struct {
+	record *game.Record
+	states []game.GameState
+	moves  []game.Move
+	ply    int // 当前显示 states[ply]，即第 ply 步之前的局面
+
+	mu          sync.Mutex
+	report      *review.Report
+	byPly       map[int]review.MoveReview
+	done, total int
+	err         error
+	stop        atomic.Bool // 离开复盘时置位，后台不再继续搜索
+
+	message string
+}
+
+// startReview 重放记录并在后台启动复盘
+func (g *GameView) startReview() {
+	states, moves, err := g.record.Replay()
+	if err != nil {
+		g.message = "Review failed: " + err.Error()
+		return
+	}
+	r := &reviewMode{record: g.record, states: states, moves: moves}
+	go func() {
+		rep, err := review.Review(r.record, reviewDepth, &r.stop, func(done, total int) {
+			r.mu.Lock()
+			r.done, r.total = done, total
+			r.mu.Unlock()
+		})
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.report, r.err = rep, err
+		if rep != nil {
+			r.byPly = make(map[int]review.MoveReview, len(rep.Moves))
+			for _, mr := range rep.Moves {
+				r.byPly[mr.Ply] = mr
+			}
+		}
+	}()
+	g.review = r
+}
+
+// close 中止后台复盘
+func (r *reviewMode) close() {
+	r.stop.Store(true)
+}
+
+// update 处理复盘按键；返回 true 表示退出复盘
+func (r *reviewMode) update() bool {
+	last := len(r.states) - 1
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		return true
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		r.ply = min(r.ply+1, last)
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		r.ply = max(r.ply-1, 0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		r.ply = 0
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		r.ply = last
+	case inpututil.IsKeyJustPressed(ebiten.KeyE):
+		r.export()
+	}
+	return false
+}
+
+// export 把带评注的记录写到当前目录
+func (r *reviewMode) export() {
+	r.mu.Lock()
+	rep := r.report
+	r.mu.Unlock()
+	if rep == nil {
+		r.message = "Review still running"
+		return
+	}
+	if err := rep.Annotate(r.record).Save(reviewFile); err != nil {
+		r.message = "Export failed: " + err.Error()
+		return
+	}
+	r.message = "Exported to " + reviewFile
+}
+
+func (r *reviewMode) draw(screen *ebiten.Image) {
+	screen.DrawImage(boardBG, nil)
+
+	gs := &r.states[r.ply]
+	forEachCoordinate(func(c game.Coordinate) {
+		drawStack(&gs.Board, c, screen)
+	})
+
+	r.mu.Lock()
+	mr, reviewed := r.byPly[r.ply]
+	rep, done, total, err := r.report, r.done, r.total, r.err
+	r.mu.Unlock()
+
+	// 本步着法及引擎推荐
+	header := fmt.Sprintf("Review  %d/%d", r.ply, len(r.moves))
+	if r.ply < len(r.moves) {
+		mv := r.moves[r.ply]
+		header += "  " + game.FormatMove(mv)
+		if jm, ok := mv.(game.JumpMove); ok {
+			header += fmt.Sprintf(" (%v)", jm.Player)
+			if reviewed && mr.Loss > 0 {
+				drawArrow(screen, mr.Best.From, mr.Best.To, bestArrowColor)
+			}
+			drawArrow(screen, jm.From, jm.To, playedArrowColor)
+		}
+	}
+	drawTextWithShadow(screen, header, 20, 30, color.Black, color.White)
+	if reviewed {
+		label := fmt.Sprintf("%s  loss %d", mr.Class, mr.Loss)
+		if mr.Loss > 0 {
+			label += "  best " + game.FormatMove(mr.Best)
+		}
+		drawTextWithShadow(screen, label, 20, 50, color.Black, classColors[mr.Class])
+	}
+
+	// 进度与汇总
+	y := pvTextY
+	switch {
+	case err != nil:
+		drawTextWithShadow(screen, "Review failed: "+err.Error(), 20, y, color.Black, color.White)
+	case rep == nil:
+		drawTextWithShadow(screen, fmt.Sprintf("Analysing %d/%d...", done, total), 20, y, color.Black, color.White)
+	default:
+		for _, p := range []game.Player{game.PWhite, game.PBlack} {
+			s := rep.Players[p]
+			line := fmt.Sprintf("%-5s %2d moves  best %d  good %d  inaccuracy %d  blunder %d  avg loss %.1f",
+				p, s.Moves, s.Counts[review.Best], s.Counts[review.Good],
+				s.Counts[review.Inaccuracy], s.Counts[review.Blunder], s.AverageLoss())
+			drawTextWithShadow(screen, line, 20, y, color.Black, color.White)
+			y += 20
+		}
+	}
+	if r.message != "" {
+		drawTextWithShadow(screen, r.message, 20, y+20, color.Black, color.White)
+	}
+	drawTextWithShadow(screen, "Left/Right: step  Home/End: jump  E: export  Esc: back", 20, statusY, color.Black, color.White)
+}
@@ -0,0 +1,134 @@
+// File internal/ui/ebiten/settings.go
+package ebiten
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"dvonn_go/internal/game"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SettingsFile 保存菜单与设置页中的选项，下次启动沿用
+const SettingsFile = "dvonn-settings.json"
+
+// AI 搜索深度的可选范围
+const (
+	minAILevel = 1
+	maxAILevel = 6
+)
+
+// animSpeeds 动画速度档位对应的跳子帧数
+var animSpeeds = []struct {
+	name   string
+	frames int
+}{
+	{"slow", 20},
+	{"normal", 10},
+	{"fast", 5},
+}
+
+// Settings 新对局的选项与界面设置
+type Settings struct {
+	Mode       string `json:"mode"`       // pvp / pve
+	Side       string `json:"side"`       // 人机对战时玩家执子：black / white
+	AILevel    int    `json:"ai_level"`   // AI 搜索深度
+	AutoPlace  bool   `json:"auto_place"` // 人人对战时自动摆子
+	Fullscreen bool   `json:"fullscreen"`
+	AnimSpeed  string `json:"anim_speed"` // slow / normal / fast
+	Palette    string `json:"palette"`    // 见 PaletteNames
+	Narration  string `json:"narration"`  // off / stdout / log（写入 NarrationLog）
+	Labels     bool   `json:"labels"`     // 棋盘边缘的坐标标注
+	Rotated    bool   `json:"rotated"`    // 棋盘旋转 180°
+	Clock      string `json:"clock"`      // 用时规则，见 TimeControls；off 为不计时
+	Theme      string `json:"theme"`      // 主题目录或 .zip；default 为内嵌素材
+}
+
+// DefaultSettings 与命令行默认值一致：人机对战，玩家执黑先行
+func DefaultSettings() Settings {
+	return Settings{Mode: "pve", Side: "black", AILevel: depth, AnimSpeed: "normal", Palette: "standard", Narration: "off", Labels: true, Clock: "off", Theme: "default"}
+}
+
+// LoadSettings 读取设置文件；文件不存在时返回默认设置
+func LoadSettings(path string) (Settings, error) {
+	s := DefaultSettings()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return DefaultSettings(), err
+	}
+	s.AILevel = max(minAILevel, min(s.AILevel, maxAILevel))
+	return s, nil
+}
+
+// Save 以缩进 JSON 写入文件
+func (s Settings) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// apply 使界面设置立即生效；返回未能生效的项与主题警告，供界面提示
+func (s Settings) apply() []string {
+	var problems []string
+	for _, sp := range animSpeeds {
+		if sp.name == s.AnimSpeed {
+			animFrames = sp.frames
+		}
+	}
+	if ebiten.IsFullscreen() != s.Fullscreen {
+		ebiten.SetFullscreen(s.Fullscreen)
+	}
+	warnings, err := UseTheme(s.Theme)
+	if err != nil {
+		problems = append(problems, "Theme not loaded: "+err.Error())
+	}
+	problems = append(problems, warnings...)
+	if SetPalette(s.Palette) != nil {
+		SetPalette("standard")
+	}
+	SetLabels(s.Labels)
+	SetRotated(s.Rotated)
+	to := ""
+	switch s.Narration {
+	case "stdout":
+		to = "stdout"
+	case "log":
+		to = NarrationLog
+	}
+	if err := SetNarration(to); err != nil {
+		problems = append(problems, "Narration disabled: "+err.Error())
+	}
+	return problems
+}
+
+// aiPlayer 人机对战时 AI 执的一方
+func (s Settings) aiPlayer() game.Player {
+	if s.Side == "white" {
+		return game.PBlack
+	}
+	return game.PWhite
+}
+
+// NewGameState 按模式创建开局：人机对战直接跳过摆子阶段，人人对战可选自动摆子
+func NewGameState(mode string, auto bool) game.GameState {
+	gs := game.StartState()
+	if mode == "pve" {
+		gs = game.FillPhase1Auto(&gs)
+		gs.Phase = game.Phase2
+		gs.Turn = game.MoveBlack
+	}
+	if mode == "pvp" && auto {
+		gs = game.FillPhase1Auto(&gs)
+	}
+	return gs
+}
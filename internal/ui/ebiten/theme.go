@@ -0,0 +1,297 @@
+// File internal/ui/ebiten/theme.go
+package ebiten
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"dvonn_go/internal/assets"
+	"dvonn_go/internal/game"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
+)
+
+/*
+主题：棋子贴图、棋盘配色、字体与叠子层距，可从目录或 zip 读取。
+主题根目录（或 zip 根目录 / 唯一的顶层目录）中放 theme.json：
+
+	{
+	  "name": "wood",
+	  "pieces": {"red": "red.png", "white": "white.png", "black": "black.png"},
+	  "colours": {"line": "#5a3d1e", "circle": "#d9b382", "background": "#3b2614",
+	              "red": "#c02020", "white": "#f0f0f0", "black": "#202020"},
+	  "font": "font.ttf",
+	  "font_size": 14,
+	  "layer_offset": 0.1
+	}
+
+各项都可省略，省略时取默认主题的值。贴图缺失或无法解码时，
+用 colours 中的 red / white / black 画出矢量棋子。
+*/
+
+// ThemeDir 菜单中列出的主题所在目录
+const ThemeDir = "themes"
+
+// themeManifest theme.json 的内容
+type themeManifest struct {
+	Name        string            `json:"name"`
+	Pieces      map[string]string `json:"pieces"`       // red / white / black -> 图片文件
+	Colours     map[string]string `json:"colours"`      // "#rrggbb" 或 "#rrggbbaa"
+	Font        string            `json:"font"`         // TrueType / OpenType 字体文件
+	FontSize    float64           `json:"font_size"`    // 磅
+	LayerOffset float64           `json:"layer_offset"` // 每层棋子的垂直偏移，以格半径为单位
+}
+
+// Theme 读取好的主题
+type Theme struct {
+	Name        string
+	pieces      map[game.Piece]*ebiten.Image
+	line        color.RGBA
+	circle      color.RGBA
+	background  color.RGBA
+	face        font.Face
+	layerOffset float64
+
+	Warnings []string // 读取时退回默认值的项，如缺失的贴图
+}
+
+var (
+	theme            = defaultTheme()
+	backgroundColor  = theme.background
+	uiFace           = theme.face
+	layerOffsetRatio = theme.layerOffset
+)
+
+// 默认配色：与原先的常量一致；矢量棋子的颜色
+var defaultColours = map[string]color.RGBA{
+	"line":       {0x44, 0x44, 0x44, 0xFF},
+	"circle":     {0xB0, 0xC4, 0xDE, 0xFF},
+	"background": {0x00, 0x00, 0x00, 0xFF},
+	"red":        {0xC8, 0x20, 0x20, 0xFF},
+	"white":      {0xF0, 0xF0, 0xF0, 0xFF},
+	"black":      {0x20, 0x20, 0x20, 0xFF},
+}
+
+var pieceKeys = map[string]game.Piece{"red": game.Red, "white": game.White, "black": game.Black}
+
+// defaultTheme 内嵌的贴图与默认配色
+func defaultTheme() *Theme {
+	t := &Theme{
+		Name:        "default",
+		pieces:      map[game.Piece]*ebiten.Image{},
+		line:        defaultColours["line"],
+		circle:      defaultColours["circle"],
+		background:  defaultColours["background"],
+		face:        basicfont.Face7x13,
+		layerOffset: 6.0 / 70,
+	}
+	for _, p := range pieceKeys {
+		t.pieces[p] = ebiten.NewImageFromImage(assets.Piece(p))
+	}
+	return t
+}
+
+// LoadTheme 从目录或 .zip 读取主题；"" 或 "default" 返回默认主题
+func LoadTheme(dir string) (*Theme, error) {
+	if dir == "" || dir == "default" {
+		return defaultTheme(), nil
+	}
+	var fsys fs.FS
+	if strings.EqualFold(filepath.Ext(dir), ".zip") {
+		zr, err := zip.OpenReader(dir)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		fsys = zr
+	} else {
+		fsys = os.DirFS(dir)
+	}
+	return loadThemeFS(fsys, strings.TrimSuffix(filepath.Base(dir), filepath.Ext(dir)))
+}
+
+// loadThemeFS 读取 fsys 中的主题；theme.json 也可以在唯一的顶层目录里（常见的 zip 打包方式）
+func loadThemeFS(fsys fs.FS, name string) (*Theme, error) {
+	if _, err := fs.Stat(fsys, "theme.json"); errors.Is(err, fs.ErrNotExist) {
+		if m, _ := fs.Glob(fsys, "*/theme.json"); len(m) == 1 {
+			sub, err := fs.Sub(fsys, path.Dir(m[0]))
+			if err != nil {
+				return nil, err
+			}
+			fsys = sub
+		}
+	}
+	data, err := fs.ReadFile(fsys, "theme.json")
+	if err != nil {
+		return nil, err
+	}
+	var m themeManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("theme.json: %w", err)
+	}
+
+	t := defaultTheme()
+	t.Name = name
+	if m.Name != "" {
+		t.Name = m.Name
+	}
+	colours := map[string]color.RGBA{}
+	for k, v := range defaultColours {
+		colours[k] = v
+	}
+	for k, v := range m.Colours {
+		c, err := parseHexColour(v)
+		if err != nil {
+			t.Warnings = append(t.Warnings, fmt.Sprintf("colour %s: %v", k, err))
+			continue
+		}
+		colours[k] = c
+	}
+	t.line, t.circle, t.background = colours["line"], colours["circle"], colours["background"]
+
+	for key, p := range pieceKeys {
+		file, ok := m.Pieces[key]
+		if !ok {
+			continue
+		}
+		img, err := decodeThemeImage(fsys, file)
+		if err != nil {
+			t.Warnings = append(t.Warnings, fmt.Sprintf("%s piece: %v, using a drawn piece", key, err))
+			t.pieces[p] = vectorPiece(colours[key])
+			continue
+		}
+		t.pieces[p] = ebiten.NewImageFromImage(img)
+	}
+
+	if m.Font != "" {
+		face, err := loadFace(fsys, m.Font, m.FontSize)
+		if err != nil {
+			t.Warnings = append(t.Warnings, fmt.Sprintf("font: %v", err))
+		} else {
+			t.face = face
+		}
+	}
+	if m.LayerOffset > 0 {
+		t.layerOffset = m.LayerOffset
+	}
+	return t, nil
+}
+
+func decodeThemeImage(fsys fs.FS, file string) (image.Image, error) {
+	f, err := fsys.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+func loadFace(fsys fs.FS, file string, size float64) (font.Face, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		size = 13
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// vectorPiece 画一枚矢量棋子：实心圆加深色外圈，供贴图缺失时使用
+func vectorPiece(fill color.RGBA) *ebiten.Image {
+	const size = 64
+	img := ebiten.NewImage(size, size)
+	rim := color.RGBA{fill.R / 2, fill.G / 2, fill.B / 2, 0xFF}
+	vector.DrawFilledCircle(img, size/2, size/2, size/2-2, fill, true)
+	vector.StrokeCircle(img, size/2, size/2, size/2-3, 3, rim, true)
+	vector.StrokeCircle(img, size/2, size/2, size/4, 2, rim, true)
+	return img
+}
+
+// parseHexColour 解析 "#rrggbb" 或 "#rrggbbaa"
+func parseHexColour(s string) (color.RGBA, error) {
+	var c color.RGBA
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	switch len(s) {
+	case 6:
+		c.A = 0xFF
+		_, err := fmt.Sscanf(s, "%02x%02x%02x", &c.R, &c.G, &c.B)
+		return c, err
+	case 8:
+		_, err := fmt.Sscanf(s, "%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A)
+		return c, err
+	}
+	return c, fmt.Errorf("want #rrggbb, got %q", s)
+}
+
+// SetTheme 切换主题；非标准配色（无障碍）仍覆盖主题的网格与格子颜色
+func SetTheme(t *Theme) {
+	theme = t
+	imgRed, imgWhite, imgBlack = t.pieces[game.Red], t.pieces[game.White], t.pieces[game.Black]
+	lineColor, circleColor, backgroundColor = t.line, t.circle, t.background
+	uiFace, layerOffsetRatio = t.face, t.layerOffset
+	if paletteName != "standard" {
+		SetPalette(paletteName)
+	} else if screenW > 0 {
+		relayout(screenW, screenH)
+	}
+}
+
+// themePath 当前主题的路径，避免重复读取
+var themePath = "default"
+
+// UseTheme 读取并切换到 path 处的主题；已是当前主题时什么也不做。
+// 读取失败时保持原主题；贴图等缺项不算失败，以警告返回，由调用方展示。
+func UseTheme(path string) ([]string, error) {
+	if path == "" {
+		path = "default"
+	}
+	if path == themePath {
+		return nil, nil
+	}
+	t, err := LoadTheme(path)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, w := range t.Warnings {
+		warnings = append(warnings, "Theme "+t.Name+": "+w)
+	}
+	themePath = path
+	SetTheme(t)
+	return warnings, nil
+}
+
+// ThemeNames 返回 ThemeDir 中的主题（子目录与 .zip），第一项为 "default"
+func ThemeNames() []string {
+	names := []string{"default"}
+	entries, err := os.ReadDir(ThemeDir)
+	if err != nil {
+		return names
+	}
+	for _, e := range entries {
+		if e.IsDir() || strings.EqualFold(filepath.Ext(e.Name()), ".zip") {
+			names = append(names, filepath.Join(ThemeDir, e.Name()))
+		}
+	}
+	return names
+}